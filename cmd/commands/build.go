@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/xreception/go-swagen/config"
+	"github.com/xreception/go-swagen/factory"
+)
+
+// Build is a command that runs merge, filter and generate as described in the config file
+type Build struct {
+	Config flags.Filename `long:"config" short:"c" description:"the project config file" default:"swagen.yaml"`
+	Check  bool           `long:"check" description:"compare every output with the files on disk instead of writing them, fail on difference"`
+	Force  bool           `long:"force" description:"overwrite or remove generated files even if they were edited by hand"`
+}
+
+// Execute runs the pipeline
+func (c *Build) Execute(args []string) error {
+	if len(args) != 0 {
+		c.Config = flags.Filename(args[0])
+	}
+	if len(c.Config) == 0 {
+		c.Config = config.DefaultFile
+	}
+
+	opts, err := config.Load(string(c.Config))
+	if err != nil {
+		return err
+	}
+
+	if err := registerPlugins(opts); err != nil {
+		return err
+	}
+
+	if opts.Merge != nil {
+		var inputs []string
+		for _, in := range opts.Merge.Inputs {
+			inputs = append(inputs, in.String())
+		}
+		merge := &Merge{
+			CompressLevel: opts.Merge.Compress,
+			Inputs:        inputs,
+			Output:        flags.Filename(opts.Merge.Output),
+			Pretty:        opts.Merge.Pretty,
+			Check:         c.Check,
+		}
+		if err := merge.Execute(nil); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range opts.Filters {
+		fmt.Printf("# Filter profile %s\n", f.Name)
+		filter := &Filter{
+			Input:  flags.Filename(f.Input),
+			Output: flags.Filename(f.Output),
+			Pretty: f.Pretty,
+			Tags:   f.Tags,
+			Check:  c.Check,
+		}
+		if err := filter.Execute(nil); err != nil {
+			return err
+		}
+	}
+
+	for _, g := range opts.Generators {
+		generate := &Generate{
+			Lang:      g.Lang,
+			Input:     flags.Filename(g.Input),
+			Output:    g.Output,
+			Check:     c.Check,
+			Force:     c.Force,
+			Templates: g.Templates,
+			options:   g.GeneratorOptions(),
+		}
+		if err := generate.Execute(nil); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println("# Build Successfully!")
+
+	return nil
+}
+
+var discovery sync.Once
+
+// discoverPlugins makes the swagen-gen-<name> executables on PATH available as generators,
+// only the commands using generators look for them, once
+func discoverPlugins() {
+	discovery.Do(func() {
+		for _, err := range factory.DiscoverPlugins(filepath.SplitList(os.Getenv("PATH"))) {
+			fmt.Fprintf(os.Stderr, "# Skip plugin: %v\n", err)
+		}
+	})
+}
+
+// registerPlugins registers the plugins configured in the config file, they win over the ones on PATH
+func registerPlugins(opts *config.Opts) error {
+	for name, path := range opts.Plugins {
+		path, err := exec.LookPath(path)
+		if err != nil {
+			return err
+		}
+		if err := factory.RegisterPlugin(name, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"path"
 
 	flags "github.com/jessevdk/go-flags"
+	"github.com/xreception/go-swagen/config"
 	"github.com/xreception/go-swagen/filter"
 	"github.com/xreception/go-swagen/utils"
 )
@@ -17,6 +18,7 @@ type Filter struct {
 	Output flags.Filename `long:"output" short:"o" description:"the file to write to"`
 	Pretty bool           `long:"pretty" short:"p" description:"Prettify your output or not"`
 	Tags   []string       `long:"tags" short:"t" description:"filter by tags"`
+	Check  bool           `long:"check" description:"compare the output with the file on disk instead of writing it, fail on difference"`
 }
 
 // Execute the command
@@ -26,13 +28,13 @@ func (c *Filter) Execute(args []string) error {
 	}
 	if len(c.Output) == 0 {
 		// return errors.New("must define output directory, plz use -o")
-		c.Output = "./build/swagger.json"
+		c.Output = config.DefaultSpec
 	}
 	if _, err := os.Stat(string(c.Input)); os.IsNotExist(err) {
 		return errors.New("input file does not exist")
 	}
 	dir := path.Dir(string(c.Output))
-	if _, err := os.Stat(dir); os.IsNotExist(err) {
+	if _, err := os.Stat(dir); os.IsNotExist(err) && !c.Check {
 		fmt.Println("# Creating output folder ...")
 		err := os.MkdirAll(dir, os.ModePerm)
 		if err != nil {
@@ -48,7 +50,10 @@ func (c *Filter) Execute(args []string) error {
 		return err
 	}
 	s := filter.Filter(swagger, c.Tags)
-	err = utils.WriteToFile(s, c.Pretty, string(c.Output))
+	if c.Check {
+		return checkSpec(s, c.Pretty, string(c.Output))
+	}
+	_, err = writeSpec(s, c.Pretty, string(c.Output))
 	if err != nil {
 		return err
 	}
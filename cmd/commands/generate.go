@@ -8,7 +8,9 @@ import (
 	"fmt"
 
 	flags "github.com/jessevdk/go-flags"
+	"github.com/xreception/go-swagen/config"
 	"github.com/xreception/go-swagen/factory"
+	"github.com/xreception/go-swagen/output"
 	"github.com/xreception/go-swagen/utils"
 )
 
@@ -16,11 +18,52 @@ import (
 type Generate struct {
 	Lang   string         `long:"lang" short:"l" description:"target language of client sdk"`
 	Input  flags.Filename `long:"input" short:"i" desciprtion:"input swagger files, you could use scope@filename if want to put a scope for the swagger"`
-	Output string         `long:"output" short:"o" description:"the path to write to"`
+	Output string         `long:"output" short:"o" description:"the folder to write to, a .zip, .tar or .tar.gz archive, or - for stdout"`
+	// Options are passed to the generator factory, they override the options from the config file
+	Options     []string `long:"option" short:"O" description:"generator option in key=value format, could be repeated"`
+	Templates   string   `long:"templates" description:"folder of .tmpl files overriding the built-in templates of the generator"`
+	HelpOptions bool     `long:"help-options" description:"print the options accepted by the generator of given language"`
+	Check       bool     `long:"check" description:"compare the generated code with the files on disk instead of writing them, fail on difference"`
+	Force       bool     `long:"force" description:"overwrite or remove generated files even if they were edited by hand"`
+
+	// options come from the config file
+	options map[string]interface{}
+}
+
+// withTemplates passes the templates folder as the templates option of the generator,
+// a generator without the option, e.g. a plugin, has no templates to override
+func withTemplates(lang string, parameters map[string]interface{}, templates string) error {
+	if len(templates) == 0 {
+		return nil
+	}
+	opts, err := factory.GetOptions(lang)
+	if err != nil {
+		return err
+	}
+	if _, ok := opts.Lookup("templates"); !ok {
+		return fmt.Errorf("generator %s has no templates to override, plz remove templates", lang)
+	}
+	parameters["templates"] = templates
+	return nil
 }
 
 // Execute expands the spec
 func (c *Generate) Execute(args []string) error {
+	discoverPlugins()
+	if len(c.Lang) == 0 {
+		c.Lang = config.DefaultLang
+		// return errors.New("Plz define the target language to generate specific client sdk, use -l")
+	}
+	if c.HelpOptions {
+		opts, err := factory.GetOptions(c.Lang)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("# Options of %s generator\n", c.Lang)
+		opts.Print(os.Stdout)
+		return nil
+	}
+
 	if len(args) != 0 {
 		c.Input = flags.Filename(args[0])
 	}
@@ -30,39 +73,73 @@ func (c *Generate) Execute(args []string) error {
 	}
 	if len(c.Output) == 0 {
 		// return errors.New("must define output directory, plz use -o")
-		c.Output = "./build/gen"
-	}
-	if len(c.Lang) == 0 {
-		c.Lang = "typescript"
-		// return errors.New("Plz define the target language to generate specific client sdk, use -l")
+		c.Output = config.DefaultOutput
 	}
 	if _, err := os.Stat(string(c.Input)); os.IsNotExist(err) {
 		return errors.New("input file does not exist")
 	}
-	if _, err := os.Stat(c.Output); os.IsNotExist(err) {
-		fmt.Println("# Creating output folder ...")
+	parameters, err := factory.ParseOptions(c.Options)
+	if err != nil {
+		return err
+	}
+	for k, v := range c.options {
+		if _, ok := parameters[k]; !ok {
+			parameters[k] = v
+		}
+	}
+	if err := withTemplates(c.Lang, parameters, c.Templates); err != nil {
+		return err
+	}
+	gen, err := factory.Create(c.Lang, parameters)
+	if err != nil {
+		return err
+	}
+	out, err := output.Open(c.Output, c.Force)
+	if err != nil {
+		return err
+	}
+	_, isDir := out.(*output.Dir)
+	if c.Check && !isDir {
+		return errors.New("--check only works with an output folder")
+	}
+
+	// keep stdout clean when the code is printed there
+	status := os.Stdout
+	if c.Output == "-" {
+		status = os.Stderr
+	}
+	if _, err := os.Stat(c.Output); os.IsNotExist(err) && isDir && !c.Check {
+		fmt.Fprintln(status, "# Creating output folder ...")
 		err := os.MkdirAll(c.Output, os.ModePerm)
 		if err != nil {
 			return err
 		}
-		fmt.Printf("# Folder %s is created.\n", c.Output)
+		fmt.Fprintf(status, "# Folder %s is created.\n", c.Output)
 	}
 
-	fmt.Printf("# Generating %s code ...\n", c.Lang)
+	fmt.Fprintf(status, "# Generating %s code ...\n", c.Lang)
 	swagger, err := utils.LoadSpec(string(c.Input))
 	if err != nil {
 		return err
 	}
-	gen, err := factory.Create(c.Lang, map[string]interface{}{})
+	// render in memory first, a failing generator leaves the output untouched
+	mem := output.NewMemory()
+	if err := gen.Parse(swagger, mem); err != nil {
+		return err
+	}
+	if c.Check {
+		return checkOutput(mem, c.Output)
+	}
+	err = mem.CopyTo(out)
 	if err != nil {
 		return err
 	}
-	err = gen.Parse(swagger, c.Output)
+	err = out.Close()
 	if err != nil {
-		panic(err)
+		return err
 	}
 
-	fmt.Println("# Generated Successfully!")
+	fmt.Fprintln(status, "# Generated Successfully!")
 
 	return nil
 }
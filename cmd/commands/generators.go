@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/xreception/go-swagen/factory"
+)
+
+// Generators is a command that lists the registered generators
+type Generators struct {
+	JSON bool `long:"json" description:"print the generators as json"`
+}
+
+// Execute prints the generators
+func (c *Generators) Execute(args []string) error {
+	discoverPlugins()
+	infos := factory.List()
+	if c.JSON {
+		b, err := json.MarshalIndent(infos, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	for _, info := range infos {
+		fmt.Printf("# %s\n", info.Name)
+		fmt.Printf("%s\n\n", info.Description)
+		fmt.Printf("Features:\n  %s\n", strings.Join(info.Features, "\n  "))
+		fmt.Printf("Outputs:\n  %s\n", strings.Join(info.Outputs, "\n  "))
+		fmt.Println("Options:")
+		info.Options.Print(os.Stdout)
+		fmt.Println()
+	}
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"path"
 
 	flags "github.com/jessevdk/go-flags"
+	"github.com/xreception/go-swagen/config"
 	"github.com/xreception/go-swagen/merger"
 	"github.com/xreception/go-swagen/utils"
 )
@@ -17,6 +18,7 @@ type Merge struct {
 	Inputs        []string       `long:"input" short:"i" desciprtion:"input swagger files, you could use scope@filename if want to put a scope for the swagger"`
 	Output        flags.Filename `long:"output" short:"o" description:"the file to write to"`
 	Pretty        bool           `long:"pretty" short:"p" description:"Prettify your output or not"`
+	Check         bool           `long:"check" description:"compare the output with the file on disk instead of writing it, fail on difference"`
 }
 
 // Execute expands the spec
@@ -34,10 +36,10 @@ func (c *Merge) Execute(args []string) error {
 	}
 	if len(c.Output) == 0 {
 		// return errors.New("must define output directory, plz use -o")
-		c.Output = "./build/swagger.json"
+		c.Output = config.DefaultSpec
 	}
 	dir := path.Dir(string(c.Output))
-	if _, err := os.Stat(dir); os.IsNotExist(err) {
+	if _, err := os.Stat(dir); os.IsNotExist(err) && !c.Check {
 		err := os.MkdirAll(dir, os.ModePerm)
 		if err != nil {
 			return err
@@ -54,7 +56,10 @@ func (c *Merge) Execute(args []string) error {
 	if err != nil {
 		return err
 	}
-	err = utils.WriteToFile(output, c.Pretty, string(c.Output))
+	if c.Check {
+		return checkSpec(output, c.Pretty, string(c.Output))
+	}
+	_, err = writeSpec(output, c.Pretty, string(c.Output))
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/go-openapi/spec"
+	"github.com/xreception/go-swagen/output"
+	"github.com/xreception/go-swagen/utils"
+)
+
+// errDrift is returned by --check when the files on disk are not up to date
+var errDrift = errors.New("files on disk are out of date, plz regenerate them")
+
+// checkOutput prints the difference between the rendered files and the ones in dir
+func checkOutput(mem *output.Memory, dir string) error {
+	diff, err := mem.Diff(dir)
+	if err != nil {
+		return err
+	}
+	if len(diff) != 0 {
+		fmt.Print(diff)
+		return errDrift
+	}
+
+	fmt.Println("# Up to date!")
+	return nil
+}
+
+// checkSpec prints the difference between the swagger and the file
+func checkSpec(swagger *spec.Swagger, pretty bool, file string) error {
+	data, err := utils.MarshalSpec(swagger, pretty)
+	if err != nil {
+		return err
+	}
+
+	mem := output.NewMemory()
+	if err := mem.WriteFile(filepath.Base(file), data); err != nil {
+		return err
+	}
+	return checkOutput(mem, filepath.Dir(file))
+}
+
+// writeSpec writes the swagger to file if its content changed
+func writeSpec(swagger *spec.Swagger, pretty bool, file string) ([]output.Change, error) {
+	data, err := utils.MarshalSpec(swagger, pretty)
+	if err != nil {
+		return nil, err
+	}
+
+	out := output.NewDir(filepath.Dir(file))
+	if err := out.WriteFile(filepath.Base(file), data); err != nil {
+		return nil, err
+	}
+	return out.Changes(), nil
+}
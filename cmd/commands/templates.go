@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/xreception/go-swagen/config"
+	"github.com/xreception/go-swagen/factory"
+	"github.com/xreception/go-swagen/utils"
+)
+
+// Templates groups the commands working on the built-in templates of generators
+type Templates struct{}
+
+// DumpTemplates is a command that prints the built-in templates of a generator with their dependencies
+type DumpTemplates struct {
+	Lang string `long:"lang" short:"l" description:"generator whose templates are printed"`
+}
+
+// Execute prints the templates
+func (c *DumpTemplates) Execute(args []string) error {
+	if len(c.Lang) == 0 {
+		c.Lang = config.DefaultLang
+	}
+	repo, err := factory.GetTemplates(c.Lang)
+	if err != nil {
+		return err
+	}
+	repo.DumpTemplates()
+	return nil
+}
+
+// ExportTemplates is a command that writes the built-in templates of a generator to a folder,
+// as a starting point for --templates
+type ExportTemplates struct {
+	Lang  string `long:"lang" short:"l" description:"generator whose templates are exported"`
+	Force bool   `long:"force" description:"overwrite the files which exist in the folder"`
+}
+
+// Execute writes the templates
+func (c *ExportTemplates) Execute(args []string) error {
+	if len(args) != 1 {
+		return errors.New("must have one target folder, e.g. swagen templates export --lang typescript ./templates")
+	}
+	if len(c.Lang) == 0 {
+		c.Lang = config.DefaultLang
+	}
+	repo, err := factory.GetTemplates(c.Lang)
+	if err != nil {
+		return err
+	}
+
+	dir := args[0]
+	files := repo.Files()
+	if !c.Force {
+		for _, name := range utils.SortedStringKeys(files) {
+			if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+				return fmt.Errorf("%s exists, plz use --force to overwrite it", filepath.Join(dir, name))
+			}
+		}
+	}
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+	for _, name := range utils.SortedStringKeys(files) {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), files[name], 0644); err != nil {
+			return err
+		}
+		fmt.Printf("# Exported %s\n", filepath.Join(dir, name))
+	}
+	return nil
+}
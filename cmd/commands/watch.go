@@ -0,0 +1,327 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/spec"
+	flags "github.com/jessevdk/go-flags"
+	"github.com/xreception/go-swagen/config"
+	"github.com/xreception/go-swagen/factory"
+	"github.com/xreception/go-swagen/filter"
+	"github.com/xreception/go-swagen/merger"
+	"github.com/xreception/go-swagen/output"
+	"github.com/xreception/go-swagen/utils"
+	"github.com/xreception/go-swagen/watcher"
+)
+
+// Watch is a command that reruns the pipeline when input swagger files change
+type Watch struct {
+	Config   flags.Filename `long:"config" short:"c" description:"the project config file, used when no input is given" default:"swagen.yaml"`
+	Input    flags.Filename `long:"input" short:"i" description:"watch a single swagger file and generate code from it instead of using the config file"`
+	Lang     string         `long:"lang" short:"l" description:"target language of client sdk when watching a single file"`
+	Output   string         `long:"output" short:"o" description:"the folder to write to when watching a single file"`
+	Options  []string       `long:"option" short:"O" description:"generator option in key=value format when watching a single file, could be repeated"`
+	Debounce time.Duration  `long:"debounce" description:"wait until files are quiet for this long before rerunning" default:"300ms"`
+	Poll     bool           `long:"poll" description:"poll the files instead of using file system events"`
+	Interval time.Duration  `long:"interval" description:"polling interval" default:"1s"`
+	Force    bool           `long:"force" description:"overwrite or remove generated files even if they were edited by hand"`
+}
+
+// Execute watches the inputs until interrupted
+func (c *Watch) Execute(args []string) error {
+	discoverPlugins()
+	if len(args) != 0 {
+		c.Input = flags.Filename(args[0])
+	}
+
+	p, err := c.load()
+	if err != nil {
+		return err
+	}
+	p.run(nil)
+
+	for {
+		files := p.inputs()
+		if len(c.Input) == 0 {
+			files = append(files, string(c.Config))
+		}
+		w, err := watcher.New(files, c.Debounce, c.Poll, c.Interval)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(p.status(), "# Watching %d files ...\n", len(files))
+
+		reload := false
+		for !reload {
+			select {
+			case changed := <-w.Changes():
+				if len(c.Input) == 0 && contains(changed, string(c.Config)) {
+					reload = true
+					continue
+				}
+				p.run(changed)
+			case err := <-w.Errors():
+				fmt.Fprintf(p.status(), "# Watch error: %v\n", err)
+			}
+		}
+		w.Close()
+
+		fmt.Fprintf(p.status(), "# %s changed, reloading ...\n", c.Config)
+		next, err := c.load()
+		if err != nil {
+			fmt.Fprintf(p.status(), "# Keep the previous config: %v\n", err)
+		} else {
+			p = next
+		}
+		p.run(nil)
+	}
+}
+
+// load builds the pipeline from the flags or the config file
+func (c *Watch) load() (*pipeline, error) {
+	if len(c.Input) == 0 {
+		opts, err := config.Load(string(c.Config))
+		if err != nil {
+			return nil, err
+		}
+		if err := registerPlugins(opts); err != nil {
+			return nil, err
+		}
+		return &pipeline{opts, c.Force}, nil
+	}
+
+	if len(c.Lang) == 0 {
+		c.Lang = config.DefaultLang
+	}
+	if len(c.Output) == 0 {
+		c.Output = config.DefaultOutput
+	}
+	parameters, err := factory.ParseOptions(c.Options)
+	if err != nil {
+		return nil, err
+	}
+	return &pipeline{&config.Opts{
+		Generators: []config.Generator{
+			{Lang: c.Lang, Input: string(c.Input), Output: c.Output, Options: parameters},
+		},
+	}, c.Force}, nil
+}
+
+// pipeline runs the stages of a config quietly and reports what changed
+type pipeline struct {
+	opts  *config.Opts
+	force bool
+}
+
+// status returns where the reports are printed, stderr if a generator prints its code to stdout
+func (p *pipeline) status() io.Writer {
+	for _, g := range p.opts.Generators {
+		if g.Output == "-" {
+			return os.Stderr
+		}
+	}
+	return os.Stdout
+}
+
+// inputs returns the files read by the stages which are not written by another stage
+func (p *pipeline) inputs() []string {
+	written := make(map[string]bool)
+	var reads []string
+	if m := p.opts.Merge; m != nil {
+		written[abs(m.Output)] = true
+		for _, in := range m.Inputs {
+			reads = append(reads, in.File)
+		}
+	}
+	for _, f := range p.opts.Filters {
+		written[abs(f.Output)] = true
+		reads = append(reads, f.Input)
+	}
+	for _, g := range p.opts.Generators {
+		reads = append(reads, g.Input)
+	}
+
+	var files []string
+	seen := make(map[string]bool)
+	for _, file := range reads {
+		if !written[abs(file)] && !seen[abs(file)] {
+			seen[abs(file)] = true
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// run executes the stages reading one of the changed files, all stages if changed is nil.
+// A stage whose output changed makes the stages reading that output run too.
+func (p *pipeline) run(changed []string) {
+	all := changed == nil
+	dirty := make(map[string]bool)
+	for _, file := range changed {
+		dirty[abs(file)] = true
+	}
+	if all {
+		fmt.Fprintf(p.status(), "# [%s] building everything\n", time.Now().Format("15:04:05"))
+	} else {
+		fmt.Fprintf(p.status(), "# [%s] changed: %s\n", time.Now().Format("15:04:05"), strings.Join(relative(changed), ", "))
+	}
+
+	if m := p.opts.Merge; m != nil {
+		run := all
+		for _, in := range m.Inputs {
+			run = run || dirty[abs(in.File)]
+		}
+		if run {
+			changes, err := p.merge()
+			p.report("merge", m.Output, changes, err)
+			if len(changes) != 0 {
+				dirty[abs(m.Output)] = true
+			}
+		}
+	}
+
+	for _, f := range p.opts.Filters {
+		if all || dirty[abs(f.Input)] {
+			changes, err := p.filter(f)
+			p.report("filter "+f.Name, f.Output, changes, err)
+			if len(changes) != 0 {
+				dirty[abs(f.Output)] = true
+			}
+		}
+	}
+
+	for _, g := range p.opts.Generators {
+		if all || dirty[abs(g.Input)] {
+			changes, err := p.generate(g)
+			p.report(g.Lang, g.Output, changes, err)
+		}
+	}
+}
+
+func (p *pipeline) merge() ([]output.Change, error) {
+	var inputs []string
+	for _, in := range p.opts.Merge.Inputs {
+		inputs = append(inputs, in.String())
+	}
+	swaggers, scopes, err := utils.LoadSpecsWithScopes(inputs)
+	if err != nil {
+		return nil, err
+	}
+	for i, swagger := range swaggers {
+		if swagger == nil {
+			return nil, fmt.Errorf("input file %s does not exist", p.opts.Merge.Inputs[i].File)
+		}
+	}
+	merged, err := merger.Merge(swaggers, scopes, nil, p.opts.Merge.Compress)
+	if err != nil {
+		return nil, err
+	}
+	return writeSpec(merged, p.opts.Merge.Pretty, p.opts.Merge.Output)
+}
+
+func (p *pipeline) filter(f config.Filter) ([]output.Change, error) {
+	swagger, err := loadSpec(f.Input)
+	if err != nil {
+		return nil, err
+	}
+	return writeSpec(filter.Filter(swagger, f.Tags), f.Pretty, f.Output)
+}
+
+func (p *pipeline) generate(g config.Generator) ([]output.Change, error) {
+	swagger, err := loadSpec(g.Input)
+	if err != nil {
+		return nil, err
+	}
+	parameters := g.GeneratorOptions()
+	if err := withTemplates(g.Lang, parameters, g.Templates); err != nil {
+		return nil, err
+	}
+	gen, err := factory.Create(g.Lang, parameters)
+	if err != nil {
+		return nil, err
+	}
+	mem := output.NewMemory()
+	if err := gen.Parse(swagger, mem); err != nil {
+		return nil, err
+	}
+	out, err := output.Open(g.Output, p.force)
+	if err != nil {
+		return nil, err
+	}
+	if err := mem.CopyTo(out); err != nil {
+		return nil, err
+	}
+	err = out.Close()
+	if dir, ok := out.(*output.Dir); ok {
+		return dir.Changes(), err
+	}
+	if err != nil {
+		return nil, err
+	}
+	// an archive or stdout is written as a whole on every run
+	return []output.Change{{Name: g.Output, Status: output.Updated}}, nil
+}
+
+// report prints one line per stage
+func (p *pipeline) report(stage string, target string, changes []output.Change, err error) {
+	if err != nil {
+		fmt.Fprintf(p.status(), "#   %-16s %s: error: %v\n", stage, target, err)
+		return
+	}
+	if len(changes) == 0 {
+		fmt.Fprintf(p.status(), "#   %-16s %s: unchanged\n", stage, target)
+		return
+	}
+
+	var files []string
+	for _, change := range changes {
+		files = append(files, fmt.Sprintf("%s %s", change.Name, change.Status))
+	}
+	fmt.Fprintf(p.status(), "#   %-16s %s: %s\n", stage, target, strings.Join(files, ", "))
+}
+
+func loadSpec(file string) (*spec.Swagger, error) {
+	swagger, err := utils.LoadSpec(file)
+	if err != nil {
+		return nil, err
+	}
+	if swagger == nil {
+		return nil, errors.New("input file " + file + " does not exist")
+	}
+	return swagger, nil
+}
+
+func abs(file string) string {
+	a, err := filepath.Abs(file)
+	if err != nil {
+		return file
+	}
+	return a
+}
+
+func relative(files []string) []string {
+	rel := make([]string, len(files))
+	for i, file := range files {
+		if r, err := filepath.Rel(abs("."), file); err == nil {
+			rel[i] = r
+		} else {
+			rel[i] = file
+		}
+	}
+	return rel
+}
+
+func contains(files []string, file string) bool {
+	for _, f := range files {
+		if abs(f) == abs(file) {
+			return true
+		}
+	}
+	return false
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/jessevdk/go-flags"
 	"github.com/xreception/go-swagen/cmd/commands"
 	_ "github.com/xreception/go-swagen/generators/react_redux_typescript"
+	_ "github.com/xreception/go-swagen/generators/template"
 	_ "github.com/xreception/go-swagen/generators/typescript"
 )
 
@@ -43,6 +44,34 @@ Generate js or ts client from given swagger file.
 		log.Fatal(err)
 	}
 
+	_, err = parser.AddCommand("generators", "list generators", "list the registered generators with their outputs and options", &commands.Generators{})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	_, err = parser.AddCommand("build", "build project", "run merge, filter and generate as described in swagen.yaml", &commands.Build{})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	_, err = parser.AddCommand("watch", "watch inputs", "rerun the affected stages of the pipeline when input swagger files change", &commands.Watch{})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	templates, err := parser.AddCommand("templates", "built-in templates", "inspect the built-in templates of generators", &commands.Templates{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	_, err = templates.AddCommand("dump", "dump templates", "print the templates of a generator, where they are defined and what they depend on", &commands.DumpTemplates{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	_, err = templates.AddCommand("export", "export templates", "write the templates of a generator to a folder as a starting point for --templates", &commands.ExportTemplates{})
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	if _, err := parser.Parse(); err != nil {
 		os.Exit(1)
 	}
@@ -1,4 +1,264 @@
 package config
 
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	// DefaultFile is the project file looked up by the build command
+	DefaultFile = "swagen.yaml"
+	// DefaultSpec is where merged or filtered swagger files are written by default
+	DefaultSpec = "./build/swagger.json"
+	// DefaultOutput is the folder generated code is written to by default
+	DefaultOutput = "./build/gen"
+	// DefaultLang is the generator used when none is given
+	DefaultLang = "typescript"
+)
+
+// Opts describes the whole swagen pipeline of a project: merge, filter and generate.
 type Opts struct {
+	Merge      *Merge      `yaml:"merge"`
+	Filters    []Filter    `yaml:"filters"`
+	Generators []Generator `yaml:"generators"`
+	// Plugins maps generator names to plugin executables
+	Plugins map[string]string `yaml:"plugins"`
+}
+
+// Merge describes the swagger files to merge into one
+type Merge struct {
+	Inputs   []Input `yaml:"inputs"`
+	Compress int     `yaml:"compress"`
+	Pretty   bool    `yaml:"pretty"`
+	Output   string  `yaml:"output"`
+}
+
+// Input is a swagger file with an optional scope
+type Input struct {
+	Scope string `yaml:"scope"`
+	File  string `yaml:"file"`
+}
+
+// Filter is a named profile which keeps the paths and definitions of the given tags
+type Filter struct {
+	Name   string   `yaml:"name"`
+	Input  string   `yaml:"input"`
+	Tags   []string `yaml:"tags"`
+	Pretty bool     `yaml:"pretty"`
+	Output string   `yaml:"output"`
+}
+
+// Generator is a target of code generation
+type Generator struct {
+	Lang    string                 `yaml:"lang"`
+	Input   string                 `yaml:"input"`
+	Output  string                 `yaml:"output"`
+	Options map[string]interface{} `yaml:"options"`
+	// Templates is a folder of .tmpl files overriding the built-in templates
+	Templates string `yaml:"templates"`
+}
+
+// UnmarshalYAML accepts both the scope@filename form used by the command line and a mapping
+func (in *Input) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err == nil {
+		ss := strings.Split(s, "@")
+		switch len(ss) {
+		case 1:
+			in.File = ss[0]
+		case 2:
+			in.Scope, in.File = ss[0], ss[1]
+		default:
+			return fmt.Errorf("at most one @ character in input %q", s)
+		}
+		return nil
+	}
+
+	type plain Input
+	return unmarshal((*plain)(in))
+}
+
+// String returns the input in scope@filename form
+func (in Input) String() string {
+	if in.Scope == "" {
+		return in.File
+	}
+	return in.Scope + "@" + in.File
+}
+
+// Load reads the project file.
+// Relative paths in the file are resolved against the folder of the file.
+func Load(file string) (*Opts, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &Opts{}
+	if err := yaml.UnmarshalStrict(data, opts); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %v", file, err)
+	}
+
+	opts.setDefaults()
+	opts.resolve(filepath.Dir(file))
+
+	return opts, opts.Validate()
+}
+
+// Validate checks the references between the stages
+func (o *Opts) Validate() error {
+	if o.Merge == nil && len(o.Filters) == 0 && len(o.Generators) == 0 {
+		return errors.New("config has nothing to do, define merge, filters or generators")
+	}
+	if o.Merge != nil {
+		if len(o.Merge.Inputs) == 0 {
+			return errors.New("merge must have inputs")
+		}
+		if o.Merge.Compress < 0 {
+			return errors.New("compress level should not lower than 0")
+		}
+	}
+
+	names := make(map[string]bool)
+	for i, f := range o.Filters {
+		if f.Name == "" {
+			return fmt.Errorf("filter #%d must have a name", i+1)
+		}
+		if names[f.Name] {
+			return fmt.Errorf("filter %s is defined twice", f.Name)
+		}
+		names[f.Name] = true
+		if f.Input == "" {
+			return fmt.Errorf("filter %s must have an input", f.Name)
+		}
+	}
+
+	for i, g := range o.Generators {
+		if g.Input == "" {
+			return fmt.Errorf("generator #%d (%s) must have an input", i+1, g.Lang)
+		}
+		// the manifest of a folder lists the files of one generator, the others' would be removed as stale
+		for j, h := range o.Generators[:i] {
+			if overlaps(g.Output, h.Output) {
+				return fmt.Errorf("generators #%d (%s) and #%d (%s) share the output %s, plz give them different outputs",
+					j+1, h.Lang, i+1, g.Lang, g.Output)
+			}
+		}
+	}
+
+	return nil
+}
+
+// FilterByName returns the filter profile with given name, or nil
+func (o *Opts) FilterByName(name string) *Filter {
+	for i := range o.Filters {
+		if o.Filters[i].Name == name {
+			return &o.Filters[i]
+		}
+	}
+	return nil
+}
+
+func (o *Opts) setDefaults() {
+	mergeOutput := ""
+	if o.Merge != nil {
+		if o.Merge.Output == "" {
+			o.Merge.Output = DefaultSpec
+		}
+		mergeOutput = o.Merge.Output
+	}
+
+	for i := range o.Filters {
+		f := &o.Filters[i]
+		if f.Input == "" {
+			f.Input = mergeOutput
+		}
+		if f.Output == "" {
+			f.Output = fmt.Sprintf("./build/%s.swagger.json", f.Name)
+		}
+	}
+
+	for i := range o.Generators {
+		g := &o.Generators[i]
+		if g.Lang == "" {
+			g.Lang = DefaultLang
+		}
+		// the input of a generator could be the name of a filter profile
+		if f := o.FilterByName(g.Input); f != nil {
+			g.Input = f.Output
+		}
+		if g.Input == "" {
+			g.Input = mergeOutput
+		}
+		// generators own their output folders, each one gets a folder of its own by default
+		if g.Output == "" {
+			g.Output = DefaultOutput + "/" + g.Lang
+		}
+		if g.Options == nil {
+			g.Options = make(map[string]interface{})
+		}
+	}
+}
+
+// GeneratorOptions returns a copy of the options passed to the generator factory,
+// the templates folder is not one of them as not every generator has templates
+func (g *Generator) GeneratorOptions() map[string]interface{} {
+	options := make(map[string]interface{}, len(g.Options))
+	for k, v := range g.Options {
+		options[k] = v
+	}
+	return options
+}
+
+func (o *Opts) resolve(dir string) {
+	for name, path := range o.Plugins {
+		// bare names are looked up in PATH
+		if strings.ContainsRune(path, '/') || strings.ContainsRune(path, filepath.Separator) {
+			o.Plugins[name] = join(dir, path)
+		}
+	}
+	if o.Merge != nil {
+		for i := range o.Merge.Inputs {
+			o.Merge.Inputs[i].File = join(dir, o.Merge.Inputs[i].File)
+		}
+		o.Merge.Output = join(dir, o.Merge.Output)
+	}
+	for i := range o.Filters {
+		o.Filters[i].Input = join(dir, o.Filters[i].Input)
+		o.Filters[i].Output = join(dir, o.Filters[i].Output)
+	}
+	for i := range o.Generators {
+		o.Generators[i].Input = join(dir, o.Generators[i].Input)
+		o.Generators[i].Output = join(dir, o.Generators[i].Output)
+		o.Generators[i].Templates = join(dir, o.Generators[i].Templates)
+	}
+}
+
+// overlaps tells whether the folders are the same or one is inside the other, - is stdout
+func overlaps(a, b string) bool {
+	if a == "-" || b == "-" {
+		return false
+	}
+	a, b = filepath.Clean(a), filepath.Clean(b)
+	if a == b {
+		return true
+	}
+	inside := func(dir, path string) bool {
+		rel, err := filepath.Rel(dir, path)
+		return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+	}
+	return inside(a, b) || inside(b, a)
+}
+
+// join resolves path against dir, an empty path, - for stdout and an absolute path are kept
+func join(dir, path string) string {
+	if path == "" || path == "-" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
 }
@@ -0,0 +1,183 @@
+package config
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want *Opts
+		err  string
+	}{
+		{
+			name: "defaults",
+			yaml: "merge:\n  inputs: [a.json]\ngenerators:\n  - {}\n  - lang: react-redux-ts\n",
+			want: &Opts{
+				Merge: &Merge{Inputs: []Input{{File: "dir/a.json"}}, Output: "dir/build/swagger.json"},
+				Generators: []Generator{
+					{Lang: "typescript", Input: "dir/build/swagger.json", Output: "dir/build/gen/typescript", Options: map[string]interface{}{}},
+					{Lang: "react-redux-ts", Input: "dir/build/swagger.json", Output: "dir/build/gen/react-redux-ts", Options: map[string]interface{}{}},
+				},
+			},
+		},
+		{
+			name: "filter as input",
+			yaml: "filters:\n  - name: account\n    input: all.json\n    tags: [Account]\ngenerators:\n  - input: account\n    output: /abs/out\n    templates: tmpl\n",
+			want: &Opts{
+				Filters: []Filter{{Name: "account", Input: "dir/all.json", Tags: []string{"Account"}, Output: "dir/build/account.swagger.json"}},
+				Generators: []Generator{
+					{Lang: "typescript", Input: "dir/build/account.swagger.json", Output: "/abs/out", Options: map[string]interface{}{}, Templates: "dir/tmpl"},
+				},
+			},
+		},
+		{
+			name: "scoped inputs and plugins",
+			yaml: "merge:\n  inputs:\n    - account@a.json\n    - scope: catalog\n      file: /c.json\nplugins:\n  go: swagen-gen-go\n  rust: ./bin/rust\n",
+			want: &Opts{
+				Merge:   &Merge{Inputs: []Input{{Scope: "account", File: "dir/a.json"}, {Scope: "catalog", File: "/c.json"}}, Output: "dir/build/swagger.json"},
+				Plugins: map[string]string{"go": "swagen-gen-go", "rust": "dir/bin/rust"},
+			},
+		},
+		{
+			name: "stdout outputs",
+			yaml: "merge:\n  inputs: [a.json]\ngenerators:\n  - output: \"-\"\n  - lang: template\n    output: \"-\"\n",
+			want: &Opts{
+				Merge: &Merge{Inputs: []Input{{File: "dir/a.json"}}, Output: "dir/build/swagger.json"},
+				Generators: []Generator{
+					{Lang: "typescript", Input: "dir/build/swagger.json", Output: "-", Options: map[string]interface{}{}},
+					{Lang: "template", Input: "dir/build/swagger.json", Output: "-", Options: map[string]interface{}{}},
+				},
+			},
+		},
+		{name: "unknown field", yaml: "merge:\n  input: [a.json]\n", err: "invalid config"},
+		{name: "two @", yaml: "merge:\n  inputs: [a@b@c.json]\n", err: "at most one @"},
+		{name: "empty", yaml: "{}\n", err: "nothing to do"},
+		{
+			name: "shared default output",
+			yaml: "merge:\n  inputs: [a.json]\ngenerators:\n  - output: gen\n  - lang: react-redux-ts\n    output: ./gen/\n",
+			err:  "share the output",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			file := filepath.Join(dir, DefaultFile)
+			if err := ioutil.WriteFile(file, []byte(tt.yaml), 0644); err != nil {
+				t.Fatal(err)
+			}
+			opts, err := Load(file)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("Load() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			want := relative(tt.want, dir)
+			if !reflect.DeepEqual(opts, want) {
+				t.Errorf("Load() = %+v, want %+v", opts, want)
+			}
+		})
+	}
+}
+
+// relative puts the paths of the want starting with dir/ into the folder of the file
+func relative(o *Opts, tmp string) *Opts {
+	fix := func(p string) string {
+		if strings.HasPrefix(p, "dir/") {
+			return filepath.Join(tmp, strings.TrimPrefix(p, "dir/"))
+		}
+		return p
+	}
+	if o.Merge != nil {
+		for i := range o.Merge.Inputs {
+			o.Merge.Inputs[i].File = fix(o.Merge.Inputs[i].File)
+		}
+		o.Merge.Output = fix(o.Merge.Output)
+	}
+	for i := range o.Filters {
+		o.Filters[i].Input = fix(o.Filters[i].Input)
+		o.Filters[i].Output = fix(o.Filters[i].Output)
+	}
+	for i := range o.Generators {
+		o.Generators[i].Input = fix(o.Generators[i].Input)
+		o.Generators[i].Output = fix(o.Generators[i].Output)
+		o.Generators[i].Templates = fix(o.Generators[i].Templates)
+	}
+	for name, path := range o.Plugins {
+		o.Plugins[name] = fix(path)
+	}
+	return o
+}
+
+func TestValidate(t *testing.T) {
+	merge := &Merge{Inputs: []Input{{File: "a.json"}}, Output: "build/swagger.json"}
+	tests := []struct {
+		name string
+		opts Opts
+		err  string
+	}{
+		{name: "nothing to do", opts: Opts{}, err: "nothing to do"},
+		{name: "merge without inputs", opts: Opts{Merge: &Merge{}}, err: "must have inputs"},
+		{name: "negative compress", opts: Opts{Merge: &Merge{Inputs: merge.Inputs, Compress: -1}}, err: "compress level"},
+		{name: "filter without name", opts: Opts{Filters: []Filter{{Input: "a.json"}}}, err: "filter #1 must have a name"},
+		{name: "filter twice", opts: Opts{Filters: []Filter{{Name: "a", Input: "a.json"}, {Name: "a", Input: "b.json"}}}, err: "defined twice"},
+		{name: "filter without input", opts: Opts{Filters: []Filter{{Name: "a"}}}, err: "filter a must have an input"},
+		{name: "generator without input", opts: Opts{Generators: []Generator{{Lang: "typescript"}}}, err: "generator #1 (typescript) must have an input"},
+		{
+			name: "generators sharing a folder",
+			opts: Opts{Merge: merge, Generators: []Generator{
+				{Lang: "typescript", Input: "a.json", Output: "build/gen"},
+				{Lang: "react-redux-ts", Input: "a.json", Output: "build/gen"},
+			}},
+			err: "generators #1 (typescript) and #2 (react-redux-ts) share the output build/gen",
+		},
+		{
+			name: "generator inside the folder of another",
+			opts: Opts{Merge: merge, Generators: []Generator{
+				{Lang: "typescript", Input: "a.json", Output: "build/gen"},
+				{Lang: "react-redux-ts", Input: "a.json", Output: "build/gen/redux"},
+			}},
+			err: "share the output",
+		},
+		{
+			name: "distinct folders",
+			opts: Opts{Merge: merge, Generators: []Generator{
+				{Lang: "typescript", Input: "a.json", Output: "build/gen/typescript"},
+				{Lang: "react-redux-ts", Input: "a.json", Output: "build/gen/react-redux-ts"},
+				{Lang: "template", Input: "a.json", Output: "build/generated"},
+			}},
+		},
+		{
+			name: "stdout twice",
+			opts: Opts{Merge: merge, Generators: []Generator{
+				{Lang: "typescript", Input: "a.json", Output: "-"},
+				{Lang: "template", Input: "a.json", Output: "-"},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if tt.err == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("Validate() error = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
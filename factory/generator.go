@@ -2,8 +2,11 @@ package factory
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/go-openapi/spec"
+	"github.com/xreception/go-swagen/generators"
+	"github.com/xreception/go-swagen/output"
 )
 
 // factories stores an internal mapping between generator names and their respective factories.
@@ -15,17 +18,40 @@ type IGeneratorFactory interface {
 	// Parameters will vary by generator and may be ignored.
 	// Each parameter key must only consist of lowercase letters and numbers.
 	Create(parameters map[string]interface{}) (IGenerator, error)
+
+	// Options returns the schema of the parameters accepted by Create.
+	Options() Options
+
+	// Info describes the generators created by the factory.
+	Info() Info
+}
+
+// ITemplatesFactory is implemented by the factories of generators built on templates.
+type ITemplatesFactory interface {
+	// Templates returns the built-in templates of the generators.
+	Templates() *generators.Repository
+}
+
+// Info describes a registered generator.
+type Info struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Features lists the parts of the swagger spec which are supported
+	Features []string `json:"features"`
+	// Outputs lists the files written by the generator, {tag} like parts are placeholders
+	Outputs []string `json:"outputs"`
+	Options Options  `json:"options"`
 }
 
 // IGenerator is created by Factory
 type IGenerator interface {
-	// Parse generate code in out folder.
+	// Parse generate code into out.
 	// The input is spec.Swagger
-	Parse(swagger *spec.Swagger, out string) error
+	Parse(swagger *spec.Swagger, out output.Output) error
 
-	// ParseFile generate code in out folder.
+	// ParseFile generate code into out.
 	// The input is the path of swagger.json file
-	ParseFile(in string, out string) error
+	ParseFile(in string, out output.Output) error
 }
 
 // Register makes a factory available by the provided name.
@@ -45,15 +71,62 @@ func Register(name string, factory IGeneratorFactory) {
 
 // Create a new Generator with the given name and parameters.
 // To use a generator, the Factory must first be registered with the given name.
-// If no drivers are found, an InvalidStorageDriverError is returned.
+// If no generators are found, an InvalidGeneratorError is returned.
+// Parameters are validated against the option schema of the factory before Create is called,
+// an InvalidOptionError is returned if they do not match.
 func Create(name string, parameters map[string]interface{}) (IGenerator, error) {
 	factory, ok := factories[name]
 	if !ok {
 		return nil, InvalidGeneratorError{name}
 	}
+	parameters, err := factory.Options().Validate(parameters)
+	if err != nil {
+		return nil, err
+	}
 	return factory.Create(parameters)
 }
 
+// GetOptions returns the option schema of the generator with the given name.
+func GetOptions(name string) (Options, error) {
+	factory, ok := factories[name]
+	if !ok {
+		return nil, InvalidGeneratorError{name}
+	}
+	return factory.Options(), nil
+}
+
+// GetTemplates returns the built-in templates of the generator with the given name.
+func GetTemplates(name string) (*generators.Repository, error) {
+	factory, ok := factories[name]
+	if !ok {
+		return nil, InvalidGeneratorError{name}
+	}
+	t, ok := factory.(ITemplatesFactory)
+	if !ok {
+		return nil, fmt.Errorf("Generator %s has no built-in templates", name)
+	}
+	return t.Templates(), nil
+}
+
+// List returns the info of all registered generators sorted by name.
+func List() []Info {
+	var names []string
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	infos := make([]Info, 0, len(names))
+	for _, name := range names {
+		factory := factories[name]
+		info := factory.Info()
+		info.Name = name
+		info.Options = factory.Options()
+		infos = append(infos, info)
+	}
+	return infos
+}
+
 // InvalidGeneratorError records an attempt to construct an unregistered generator.
 type InvalidGeneratorError struct {
 	Name string
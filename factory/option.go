@@ -0,0 +1,130 @@
+package factory
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// Types of generator options
+const (
+	TypeString = "string"
+	TypeBool   = "bool"
+	TypeInt    = "int"
+)
+
+// Option describes a parameter accepted by a generator.
+type Option struct {
+	// Name of the option, must only consist of lowercase letters and numbers
+	Name        string      `json:"name"`
+	Type        string      `json:"type"`
+	Default     interface{} `json:"default"`
+	Description string      `json:"description"`
+}
+
+// Options is the schema of the parameters accepted by a generator.
+type Options []Option
+
+// Lookup returns the option with given name
+func (opts Options) Lookup(name string) (Option, bool) {
+	for _, opt := range opts {
+		if opt.Name == name {
+			return opt, true
+		}
+	}
+	return Option{}, false
+}
+
+// Validate checks the parameters against the schema.
+// It returns a new map where string values are converted to the type of the option
+// and missing parameters are set to their defaults.
+func (opts Options) Validate(parameters map[string]interface{}) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	for name, value := range parameters {
+		opt, ok := opts.Lookup(name)
+		if !ok {
+			return nil, InvalidOptionError{name, "unknown option"}
+		}
+		v, err := opt.convert(value)
+		if err != nil {
+			return nil, InvalidOptionError{name, err.Error()}
+		}
+		result[name] = v
+	}
+
+	for _, opt := range opts {
+		if _, ok := result[opt.Name]; !ok {
+			result[opt.Name] = opt.Default
+		}
+	}
+
+	return result, nil
+}
+
+// Print writes the schema as a table
+func (opts Options) Print(w io.Writer) {
+	if len(opts) == 0 {
+		fmt.Fprintln(w, "no options")
+		return
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tTYPE\tDEFAULT\tDESCRIPTION")
+	for _, opt := range opts {
+		fmt.Fprintf(tw, "%s\t%s\t%v\t%s\n", opt.Name, opt.Type, opt.Default, opt.Description)
+	}
+	tw.Flush()
+}
+
+func (opt Option) convert(value interface{}) (interface{}, error) {
+	s, isString := value.(string)
+	switch opt.Type {
+	case TypeString:
+		if !isString {
+			return nil, fmt.Errorf("expect a string, got %v", value)
+		}
+		return s, nil
+	case TypeBool:
+		if isString {
+			return strconv.ParseBool(s)
+		}
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+		return nil, fmt.Errorf("expect a bool, got %v", value)
+	case TypeInt:
+		if isString {
+			return strconv.Atoi(s)
+		}
+		if i, ok := value.(int); ok {
+			return i, nil
+		}
+		return nil, fmt.Errorf("expect an int, got %v", value)
+	}
+
+	return nil, fmt.Errorf("unsupported option type %s", opt.Type)
+}
+
+// ParseOptions parses key=value pairs given on the command line
+func ParseOptions(pairs []string) (map[string]interface{}, error) {
+	parameters := make(map[string]interface{})
+	for _, pair := range pairs {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || len(kv[0]) == 0 {
+			return nil, fmt.Errorf("option %q should be in key=value format", pair)
+		}
+		parameters[kv[0]] = kv[1]
+	}
+	return parameters, nil
+}
+
+// InvalidOptionError records a parameter which does not match the option schema of a generator.
+type InvalidOptionError struct {
+	Name   string
+	Reason string
+}
+
+func (err InvalidOptionError) Error() string {
+	return fmt.Sprintf("Invalid option %s: %s", err.Name, err.Reason)
+}
@@ -0,0 +1,94 @@
+package factory
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOptionsValidate(t *testing.T) {
+	opts := Options{
+		{Name: "templates", Type: TypeString, Default: ""},
+		{Name: "optional", Type: TypeBool, Default: false},
+		{Name: "indent", Type: TypeInt, Default: 2},
+	}
+	tests := []struct {
+		name       string
+		parameters map[string]interface{}
+		want       map[string]interface{}
+		err        string
+	}{
+		{
+			name: "defaults",
+			want: map[string]interface{}{"templates": "", "optional": false, "indent": 2},
+		},
+		{
+			name:       "strings of the command line",
+			parameters: map[string]interface{}{"templates": "./tmpl", "optional": "true", "indent": "4"},
+			want:       map[string]interface{}{"templates": "./tmpl", "optional": true, "indent": 4},
+		},
+		{
+			name:       "values of the config file",
+			parameters: map[string]interface{}{"optional": true, "indent": 0},
+			want:       map[string]interface{}{"templates": "", "optional": true, "indent": 0},
+		},
+		{name: "unknown", parameters: map[string]interface{}{"runtime": "fetch"}, err: "Invalid option runtime: unknown option"},
+		{name: "bad bool", parameters: map[string]interface{}{"optional": "yes please"}, err: "Invalid option optional"},
+		{name: "bad int", parameters: map[string]interface{}{"indent": "two"}, err: "Invalid option indent"},
+		{name: "string expected", parameters: map[string]interface{}{"templates": 1}, err: "expect a string, got 1"},
+		{name: "bool expected", parameters: map[string]interface{}{"optional": 1}, err: "expect a bool, got 1"},
+		{name: "int expected", parameters: map[string]interface{}{"indent": 1.5}, err: "expect an int, got 1.5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := opts.Validate(tt.parameters)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("Validate() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseOptions(t *testing.T) {
+	tests := []struct {
+		name  string
+		pairs []string
+		want  map[string]interface{}
+		err   string
+	}{
+		{name: "none", want: map[string]interface{}{}},
+		{name: "pairs", pairs: []string{"optional=true", "formats=int64=string,date=Date"},
+			want: map[string]interface{}{"optional": "true", "formats": "int64=string,date=Date"}},
+		{name: "empty value", pairs: []string{"package="}, want: map[string]interface{}{"package": ""}},
+		{name: "no value", pairs: []string{"optional"}, err: "key=value format"},
+		{name: "no key", pairs: []string{"=true"}, err: "key=value format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseOptions(tt.pairs)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("ParseOptions() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseOptions() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseOptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
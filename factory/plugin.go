@@ -0,0 +1,194 @@
+package factory
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/loads"
+	"github.com/go-openapi/spec"
+	"github.com/xreception/go-swagen/output"
+)
+
+// PluginPrefix is the prefix of plugin executables, the rest of the file name is the generator name.
+const PluginPrefix = "swagen-gen-"
+
+// PluginProtocol is the version of the plugin protocol.
+//
+// A plugin is an executable which
+//
+//   - prints its PluginInfo as json when called with the --describe argument
+//   - reads a PluginRequest as json from stdin when called without arguments,
+//     and prints a PluginResponse as json to stdout
+//
+// Anything written to stderr is shown to the user, a non-zero exit code fails the generation.
+const PluginProtocol = 1
+
+// PluginInfo is printed by a plugin called with --describe
+type PluginInfo struct {
+	Info
+	Protocol int `json:"protocol"`
+}
+
+// PluginRequest is sent to the plugin on stdin
+type PluginRequest struct {
+	Protocol int                    `json:"protocol"`
+	Spec     *spec.Swagger          `json:"spec"`
+	Options  map[string]interface{} `json:"options"`
+}
+
+// PluginResponse is read from the stdout of the plugin
+type PluginResponse struct {
+	Files []PluginFile `json:"files"`
+}
+
+// PluginFile is a file to write, Path is relative to the output folder
+type PluginFile struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+// DescribeTimeout is how long a plugin may take to describe itself
+var DescribeTimeout = 10 * time.Second
+
+// RegisterPlugin registers the plugin executable at path with the provided name.
+// The plugin is asked to describe itself, its options are used to validate the parameters.
+// A plugin registered with the same name before, e.g. one discovered on PATH, is replaced,
+// a built-in generator is not.
+func RegisterPlugin(name string, path string) error {
+	if registered, ok := factories[name]; ok {
+		p, isPlugin := registered.(*pluginFactory)
+		if !isPlugin {
+			return fmt.Errorf("Factory named %s already registered", name)
+		}
+		if p.path == path {
+			return nil
+		}
+	}
+
+	f := &pluginFactory{path: path}
+	ctx, cancel := context.WithTimeout(context.Background(), DescribeTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, path, "--describe").Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("plugin %s did not describe itself within %s", path, DescribeTimeout)
+	}
+	if err != nil {
+		return fmt.Errorf("plugin %s failed to describe itself: %v", path, err)
+	}
+	if err := json.Unmarshal(out, &f.info); err != nil {
+		return fmt.Errorf("plugin %s has an invalid description: %v", path, err)
+	}
+	if f.info.Protocol != PluginProtocol {
+		return fmt.Errorf("plugin %s speaks protocol %d, expected %d", path, f.info.Protocol, PluginProtocol)
+	}
+
+	factories[name] = f
+	return nil
+}
+
+// DiscoverPlugins registers the swagen-gen-<name> executables found in dirs.
+// Generators which are already registered take precedence over plugins with the same name,
+// and earlier dirs take precedence over later ones like in PATH.
+func DiscoverPlugins(dirs []string) []error {
+	var errs []error
+	for _, dir := range dirs {
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, fi := range files {
+			if fi.IsDir() || !strings.HasPrefix(fi.Name(), PluginPrefix) || fi.Mode()&0111 == 0 {
+				continue
+			}
+			name := strings.TrimSuffix(strings.TrimPrefix(fi.Name(), PluginPrefix), ".exe")
+			if _, registered := factories[name]; registered || name == "" {
+				continue
+			}
+			if err := RegisterPlugin(name, filepath.Join(dir, fi.Name())); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errs
+}
+
+// pluginFactory implements IGeneratorFactory for plugin executables
+type pluginFactory struct {
+	path string
+	info PluginInfo
+}
+
+func (f *pluginFactory) Info() Info {
+	info := f.info.Info
+	info.Description = strings.TrimSpace(info.Description + " (plugin " + f.path + ")")
+	return info
+}
+
+func (f *pluginFactory) Options() Options {
+	return f.info.Options
+}
+
+func (f *pluginFactory) Create(parameters map[string]interface{}) (IGenerator, error) {
+	return &pluginGenerator{
+		path:       f.path,
+		parameters: parameters,
+	}, nil
+}
+
+// pluginGenerator implements IGenerator by running the plugin executable
+type pluginGenerator struct {
+	path       string
+	parameters map[string]interface{}
+}
+
+// Parse implements IGenerator's Parse method.
+func (gen *pluginGenerator) Parse(swagger *spec.Swagger, out output.Output) error {
+	req, err := json.Marshal(PluginRequest{
+		Protocol: PluginProtocol,
+		Spec:     swagger,
+		Options:  gen.parameters,
+	})
+	if err != nil {
+		return err
+	}
+
+	var stdout bytes.Buffer
+	cmd := exec.Command(gen.path)
+	cmd.Stdin = bytes.NewReader(req)
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("plugin %s failed: %v", gen.path, err)
+	}
+
+	resp := PluginResponse{}
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return fmt.Errorf("plugin %s returned an invalid response: %v", gen.path, err)
+	}
+
+	for _, file := range resp.Files {
+		if err := out.WriteFile(file.Path, []byte(file.Content)); err != nil {
+			return fmt.Errorf("plugin %s: %v", gen.path, err)
+		}
+	}
+
+	return nil
+}
+
+// ParseFile implements IGenerator's ParseFile method
+func (gen *pluginGenerator) ParseFile(in string, out output.Output) error {
+	doc, err := loads.Spec(in)
+	if err != nil {
+		return err
+	}
+
+	return gen.Parse(doc.Spec(), out)
+}
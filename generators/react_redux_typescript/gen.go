@@ -1,19 +1,18 @@
 package reactReduxTypescript
 
 import (
+	"bytes"
 	"errors"
-	"fmt"
-	"os"
-	"regexp"
 
 	"github.com/go-openapi/loads"
 	"github.com/go-openapi/spec"
 	"github.com/xreception/go-swagen/factory"
-	"github.com/xreception/go-swagen/utils"
+	"github.com/xreception/go-swagen/generators"
+	"github.com/xreception/go-swagen/model"
+	"github.com/xreception/go-swagen/output"
 )
 
 const generatorName = "react-redux-ts"
-const entityID = "uri"
 
 func init() {
 	factory.Register(generatorName, &theFactory{})
@@ -22,31 +21,101 @@ func init() {
 // theFactory implements factory.IFactory
 type theFactory struct{}
 
+func (f *theFactory) Info() factory.Info {
+	return factory.Info{
+		Description: "redux actions, fetch api and normalizr schemas in typescript",
+		Features: []string{
+			"definitions as interfaces or type aliases, optional properties unless required",
+			"allOf, additionalProperties, inline objects, enums, x-nullable and readOnly",
+			"enum definitions",
+			"operations of every http method with a 2xx response",
+			"query and body parameters",
+			"normalizr entities",
+			"identifiers escaped from reserved words, clashing names numbered in the order of the spec",
+			"camel cased properties renamed from and to their json names by a generated key map, or json names kept",
+		},
+		Outputs: []string{"action.ts", "api.ts", "constant.ts", "schema.ts", "convert.ts if a property is renamed"},
+	}
+}
+
+func (f *theFactory) Templates() *generators.Repository {
+	return templates
+}
+
+func (f *theFactory) Options() factory.Options {
+	return factory.Options{
+		{
+			Name:        "entityid",
+			Type:        factory.TypeString,
+			Default:     "uri",
+			Description: "property which makes a schema a normalizr entity",
+		},
+		{
+			Name:        "templates",
+			Type:        factory.TypeString,
+			Default:     "",
+			Description: "folder of .tmpl files overriding the built-in templates",
+		},
+		{
+			Name:        "optional",
+			Type:        factory.TypeBool,
+			Default:     false,
+			Description: "make every property optional, for backends omitting zero values like grpc-gateway",
+		},
+		{
+			Name:        "wirenames",
+			Type:        factory.TypeBool,
+			Default:     false,
+			Description: "keep the json names of properties instead of camel casing and converting them",
+		},
+	}
+}
+
 func (f *theFactory) Create(parameters map[string]interface{}) (factory.IGenerator, error) {
+	repo, err := generators.Override(templates, parameters["templates"].(string))
+	if err != nil {
+		return nil, err
+	}
+	ts := model.TypeScript{Optional: parameters["optional"].(bool), WireNames: parameters["wirenames"].(bool)}
+	repo, err = repo.Funcs(generators.TypeScriptFuncs(ts, namespace))
+	if err != nil {
+		return nil, err
+	}
+
 	return &generator{
-		Actions: make(map[string][]*Action),
-		Schemas: make(map[string]*Schema),
+		templates: repo,
+		ts:        ts,
+		entityID:  parameters["entityid"].(string),
 	}, nil
 }
 
 // generator implements factory.IGenerator
 type generator struct {
 	factory.IGenerator
-	swagger *spec.Swagger
+	model     *model.Model
+	templates *generators.Repository
+	ts        model.TypeScript
+	codecs    *model.Codecs
+	entityID  string
 
 	Schemas      map[string]*Schema
 	SchemasArray []*Schema
 	Actions      map[string][]*Action
+	ActionsArray []*Action
 }
 
 // Schema the normalizr Schema structure
 type Schema struct {
-	Name         string
+	Name string
+	// Entity is the name of the normalizr schema
+	Entity       string
 	Class        string
 	Deps         map[string]string
 	Normalizable bool
 	Props        map[string]string
 	Enum         []interface{}
+	// Definition is the schema in the model
+	Definition *model.Schema
 }
 
 // Action the readux Action
@@ -56,31 +125,49 @@ type Action struct {
 	Method     string
 	Endpoint   string
 	RespSchema *Schema
-	Parameters []spec.Parameter
+	// RespType is the typescript type of the response
+	RespType string
+	// Response is the schema of the response, nil if it has no body
+	Response *model.Schema
+	// Operation is the operation in the model
+	Operation  *model.Operation
+	Parameters []*model.Parameter
 }
 
 // Parse implements IGenerator's Parse method.
-func (gen *generator) Parse(swagger *spec.Swagger, out string) error {
-	gen.swagger = swagger
-
-	paths := gen.swagger.Paths
-	if paths == nil || paths.Paths == nil || len(paths.Paths) == 0 {
+func (gen *generator) Parse(swagger *spec.Swagger, out output.Output) error {
+	if swagger.Paths == nil || len(swagger.Paths.Paths) == 0 {
 		return errors.New("this swagger has no path")
 	}
 
-	for _, endpoint := range utils.SortedStringKeys(paths.Paths) {
-		item := paths.Paths[endpoint]
-		gen.parseOperation(endpoint, "GET", item.Get)
-		gen.parseOperation(endpoint, "PUT", item.Put)
-		gen.parseOperation(endpoint, "POST", item.Post)
-		gen.parseOperation(endpoint, "DELETE", item.Delete)
+	// the identifiers, templates, actions and schemas are the ones of this run, a generator could parse again, e.g. in watch
+	run := &generator{
+		model:    model.New(swagger),
+		ts:       gen.ts,
+		entityID: gen.entityID,
+		Actions:  make(map[string][]*Action),
+		Schemas:  make(map[string]*Schema),
+	}
+	run.ts.Identifiers = run.ts.Identify(run.model)
+	run.codecs = run.ts.Codecs(run.model)
+	funcs := generators.TypeScriptFuncs(run.ts, namespace)
+	for name, fn := range generators.CodecFuncs(run.codecs) {
+		funcs[name] = fn
+	}
+	templates, err := gen.templates.Funcs(funcs)
+	if err != nil {
+		return err
+	}
+	run.templates = templates
+	for _, op := range run.model.Operations {
+		run.parseOperation(op)
 	}
 
-	return gen.writeTo(out)
+	return run.writeTo(out)
 }
 
 // ParseFile implements IGenerator's ParseFile method
-func (gen *generator) ParseFile(in string, out string) error {
+func (gen *generator) ParseFile(in string, out output.Output) error {
 	doc, err := loads.Spec(in)
 	if err != nil {
 		return err
@@ -89,18 +176,19 @@ func (gen *generator) ParseFile(in string, out string) error {
 	return gen.Parse(doc.Spec(), out)
 }
 
-func (gen *generator) writeTo(folder string) error {
-	m := map[string]interface{}{"action": gen.Actions, "api": gen, "constant": gen.Actions, "schema": gen.SchemasArray}
+func (gen *generator) writeTo(out output.Output) error {
+	m := map[string]interface{}{"action": gen.Actions, "api": gen, "constant": gen.ActionsArray, "schema": gen.SchemasArray}
+	if gen.codecs.Enabled() {
+		m["convert"] = gen.model
+	}
 	for k, v := range m {
-		filePath := fmt.Sprintf("%s/%s.ts", folder, k)
-		file, err := os.Create(filePath)
-		defer file.Close()
-
+		var buf bytes.Buffer
+		err := gen.templates.ExecuteTemplate(&buf, k, v)
 		if err != nil {
 			return err
 		}
 
-		err = templates.ExecuteTemplate(file, k, v)
+		err = out.WriteFile(k+".ts", buf.Bytes())
 		if err != nil {
 			return err
 		}
@@ -109,153 +197,106 @@ func (gen *generator) writeTo(folder string) error {
 }
 
 // parseOperation parse the operation of swagger.
-func (gen *generator) parseOperation(endpoint string, method string, op *spec.Operation) {
-	if op == nil {
-		return
+func (gen *generator) parseOperation(op *model.Operation) {
+	for _, param := range op.Parameters {
+		gen.parseSchemaRef(param.Schema)
 	}
 
-	for i := range op.Parameters {
-		gen.parseParam(&op.Parameters[i])
+	// only pass operations with a successful response, which have a tag to be grouped by
+	resp := op.Success()
+	if resp == nil {
+		generators.Warn("skip %s %s, it has no 2xx response", op.Method, op.Path)
+		return
 	}
-
-	if op.Responses == nil || op.Responses.StatusCodeResponses == nil {
+	if len(op.Tags) == 0 {
+		generators.Warn("skip %s %s, it has no tag to be grouped into actions", op.Method, op.Path)
 		return
 	}
 
-	// TODO (junhua): only pass response with 200 for now
-	if resp, ok := op.Responses.StatusCodeResponses[200]; ok {
-		// TODO(junhua): suppose every response is a ref schema
-		schemaName := getSchemaName(resp.Schema)
-		schema := gen.parseSchema(resp.Schema, schemaName)
-		a := &Action{
-			Name:       utils.CamelCase(op.ID),
-			Type:       utils.UpperSnakeCase(op.Tags[0] + op.ID),
-			Method:     method,
-			Endpoint:   replaceWithDollar(endpoint),
-			Parameters: op.Parameters,
-		}
-
-		if schema != nil {
-			a.RespSchema = schema
-		}
-
-		for _, s := range op.Tags {
-			s = utils.CamelCase(s)
-			as, ok := gen.Actions[s]
-			if !ok {
-				as = make([]*Action, 0)
-			}
+	a := &Action{
+		Name:       gen.ts.OperationName(op),
+		Type:       gen.ts.ConstantName(op),
+		Method:     op.Method,
+		Endpoint:   gen.codecs.Path(op),
+		Parameters: op.Parameters,
+		Operation:  op,
+	}
+	if resp.Schema != nil {
+		a.RespSchema = gen.parseSchemaRef(resp.Schema)
+		a.RespType = gen.ts.Type(resp.Schema)
+		a.Response = resp.Schema
+	}
 
-			as = append(as, a)
-			gen.Actions[s] = as
-		}
+	gen.ActionsArray = append(gen.ActionsArray, a)
+	for _, tag := range op.Tags {
+		group := gen.ts.GroupName(tag)
+		gen.Actions[group] = append(gen.Actions[group], a)
 	}
 }
 
-// parseParam parse the parameters of operation
-func (gen *generator) parseParam(param *spec.Parameter) {
-	if param.Schema != nil {
-		name := getSchemaName(param.Schema)
-		gen.parseSchema(param.Schema, name)
-		param.Type = utils.InterfaceCase(name)
-	} else if param.Type == "integer" {
-		param.Type = "number"
+// parseSchemaRef parses the definitions referenced by the schema, it returns the one of the schema or its items
+func (gen *generator) parseSchemaRef(s *model.Schema) *Schema {
+	for _, ref := range s.Refs() {
+		gen.parseSchema(ref)
 	}
-}
 
-// parseSchema parse the schema.
-func (gen *generator) parseSchema(s *spec.Schema, name string) *Schema {
-	if s == nil || len(name) == 0 {
+	if s.Items != nil {
+		s = s.Items
+	}
+	if s.Ref == "" {
 		return nil
 	}
+	return gen.parseSchema(s.Ref)
+}
 
+// parseSchema parse the definition with given name.
+func (gen *generator) parseSchema(name string) *Schema {
 	if existed, ok := gen.Schemas[name]; ok {
 		return existed
 	}
 
-	if s.Ref.HasFragmentOnly {
-		data, _, err := s.Ref.GetPointer().Get(gen.swagger)
-		if err != nil {
-			fmt.Println(err) // TODO(junhua) 这里的错误可以安全吞掉
-		}
-		if data == nil {
-			return nil
-		}
-
-		nextSchema := data.(spec.Schema)
-		return gen.parseSchema(&nextSchema, name)
+	s := gen.model.Schema(name)
+	if s == nil {
+		return nil
 	}
 
 	schema := &Schema{
 		Name:         name,
+		Entity:       gen.ts.EntityName(name),
 		Deps:         make(map[string]string),
 		Props:        make(map[string]string),
 		Class:        "Object",
 		Normalizable: false,
 		Enum:         s.Enum,
+		Definition:   s,
+	}
+	// register first, a schema could refer to itself
+	gen.Schemas[name] = schema
+	for _, ref := range s.Refs() {
+		gen.parseSchema(ref)
 	}
-	for k, v := range s.Properties {
-		schema.Props[k] = getSchemaType(&v)
+	for _, prop := range s.Properties {
+		k, v := prop.Name, prop.Schema
+		schema.Props[k] = gen.ts.Type(v)
 
-		if k == entityID {
+		if k == gen.entityID {
 			schema.Class = "Entity"
 			schema.Normalizable = true
-		} else if v.Ref.HasFragmentOnly {
-			refName := getSchemaName(&v)
-			schema.Props[k] = utils.InterfaceCase(refName)
-			next := gen.parseSchema(&v, refName)
-			if next.Normalizable {
-				schema.Deps[k] = utils.CamelCase(refName)
+		} else if v.Ref != "" {
+			next := gen.parseSchema(v.Ref)
+			if next != nil && next.Normalizable {
+				schema.Deps[gen.ts.PropertyName(prop)] = next.Entity
 				schema.Normalizable = true
 			}
-		} else if isArrayOfSchema(&v) {
-			refName := getSchemaName(v.Items.Schema)
-			schema.Props[k] = utils.InterfaceCase(refName) + "[]"
-			next := gen.parseSchema(v.Items.Schema, refName)
-			if next.Normalizable {
-				schema.Deps[k] = "[" + utils.CamelCase(refName) + "]"
+		} else if v.Items != nil && v.Items.Ref != "" {
+			next := gen.parseSchema(v.Items.Ref)
+			if next != nil && next.Normalizable {
+				schema.Deps[gen.ts.PropertyName(prop)] = "[" + next.Entity + "]"
 				schema.Normalizable = true
 			}
 		}
 	}
 
 	gen.SchemasArray = append(gen.SchemasArray, schema)
-	gen.Schemas[name] = schema
 	return schema
 }
-
-func getSchemaName(s *spec.Schema) string {
-	pr := s.Ref.GetPointer()
-	tokens := pr.DecodedTokens()
-	return tokens[len(tokens)-1]
-}
-
-func getSchemaType(schema *spec.Schema) string {
-	if len(schema.Type) == 0 {
-		return ""
-	}
-
-	t := schema.Type[0]
-	a := ""
-	if t == "array" && len(schema.Items.Schema.Type) > 0 {
-		t = schema.Items.Schema.Type[0]
-		a = "[]"
-	}
-	if t == "integer" {
-		t = "number"
-	}
-	return t + a
-}
-
-func isArrayOfSchema(s *spec.Schema) bool {
-	return s.Items != nil && s.Items.Schema != nil && s.Items.Schema.Ref.HasFragmentOnly
-}
-
-func replaceWithDollar(s string) string {
-	re := regexp.MustCompile(`{[a-z0-9A-Z_-]+}`)
-	return re.ReplaceAllStringFunc(s, func(matched string) string {
-		n := len(matched)
-		w := matched[1 : n-1]
-		return "${" + utils.CamelCase(w) + "}"
-	})
-}
@@ -0,0 +1,207 @@
+package reactReduxTypescript
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/xreception/go-swagen/output"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// petstore is the swagger rendered by the golden tests of every generator
+const petstore = "../testdata/petstore.json"
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters map[string]interface{}
+	}{
+		{name: "default"},
+		{name: "entityid", parameters: map[string]interface{}{"entityid": "id", "wirenames": true}},
+	}
+
+	f := &theFactory{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parameters, err := f.Options().Validate(tt.parameters)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gen, err := f.Create(parameters)
+			if err != nil {
+				t.Fatal(err)
+			}
+			mem := output.NewMemory()
+			if err := gen.ParseFile(petstore, mem); err != nil {
+				t.Fatal(err)
+			}
+			golden(t, filepath.Join("testdata", tt.name), mem)
+		})
+	}
+}
+
+// clashes is a spec whose tags and definitions are no identifiers or clash with each other
+const clashes = `{
+  "swagger": "2.0",
+  "info": {"title": "clashes", "version": "1"},
+  "paths": {
+    "/classes/{uri}": {
+      "get": {
+        "operationId": "delete", "tags": ["class", "2fa"],
+        "parameters": [{"name": "uri", "in": "path", "required": true, "type": "string"}],
+        "responses": {"200": {"description": "ok", "schema": {"$ref": "#/definitions/class"}}}
+      },
+      "put": {
+        "operationId": "delete_", "tags": ["class"],
+        "responses": {"200": {"description": "ok", "schema": {"$ref": "#/definitions/2fa"}}}
+      }
+    },
+    "/schemas": {
+      "get": {
+        "operationId": "list", "tags": ["schema", "config"],
+        "responses": {"200": {"description": "ok", "schema": {"$ref": "#/definitions/schema"}}}
+      }
+    }
+  },
+  "definitions": {
+    "class": {"type": "object", "properties": {"uri": {"type": "string"}}},
+    "2fa": {"type": "object", "properties": {"uri": {"type": "string"}, "owner": {"$ref": "#/definitions/class"}}},
+    "schema": {"type": "object", "properties": {"uri": {"type": "string"}}}
+  }
+}`
+
+func TestGenerateClashes(t *testing.T) {
+	f := &theFactory{}
+	parameters, err := f.Options().Validate(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gen, err := f.Create(parameters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	swagger := &spec.Swagger{}
+	if err := json.Unmarshal([]byte(clashes), swagger); err != nil {
+		t.Fatal(err)
+	}
+	mem := output.NewMemory()
+	if err := gen.Parse(swagger, mem); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		file string
+		want []string
+		// once are the declarations which must be written once
+		once []string
+	}{
+		{
+			file: "api.ts",
+			want: []string{"export const class_ = {", "export const _2fa = {", "export const schema2 = {", "export const config2 = {"},
+		},
+		{
+			file: "action.ts",
+			want: []string{"export const class_ActionTypes = {", "export const _2faActionTypes = {", "export const schema2 = {", "schema: sc.class_,", "schema: sc._2fa,"},
+		},
+		{
+			file: "constant.ts",
+			want: []string{"export const CLASSDELETE2 = 'CLASSDELETE2';"},
+			once: []string{"export const CLASSDELETE = ", "export const CLASSDELETE_FAIL = ", "export const SCHEMALIST = "},
+		},
+		{
+			file: "schema.ts",
+			want: []string{"export const class_ = new schema.Entity(", "export const _2fa = new schema.Entity('2fas', { \n  owner: class_,", "export const schema2 = new schema.Entity("},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			data, ok := mem.File(tt.file)
+			if !ok {
+				t.Fatalf("no file %s", tt.file)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(data), want) {
+					t.Errorf("%s has no %q:\n%s", tt.file, want, data)
+				}
+			}
+			for _, once := range tt.once {
+				if n := strings.Count(string(data), once); n != 1 {
+					t.Errorf("%s has %d times %q, want once", tt.file, n, once)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateTwice(t *testing.T) {
+	f := &theFactory{}
+	parameters, err := f.Options().Validate(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gen, err := f.Create(parameters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	swagger := &spec.Swagger{}
+	if err := json.Unmarshal([]byte(clashes), swagger); err != nil {
+		t.Fatal(err)
+	}
+	// a generator parses again in watch, a run does not keep the actions and names of the previous one
+	if err := gen.Parse(swagger, output.NewMemory()); err != nil {
+		t.Fatal(err)
+	}
+	mem := output.NewMemory()
+	if err := gen.ParseFile(petstore, mem); err != nil {
+		t.Fatal(err)
+	}
+	golden(t, filepath.Join("testdata", "default"), mem)
+}
+
+// golden compares the files in memory with the ones in dir, or writes them to dir if -update is given
+func golden(t *testing.T, dir string, mem *output.Memory) {
+	t.Helper()
+	if *update {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Fatal(err)
+		}
+		out := output.NewDir(dir)
+		if err := mem.CopyTo(out); err != nil {
+			t.Fatal(err)
+		}
+		if err := out.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var names []string
+	err := filepath.Walk(dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil || fi.IsDir() {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		names = append(names, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(mem.Names(), names) {
+		t.Errorf("files = %v, want %v, run go test -update to accept them", mem.Names(), names)
+	}
+	diff, err := mem.Diff(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != "" {
+		t.Errorf("files differ from %s, run go test -update to accept them:\n%s", dir, diff)
+	}
+}
@@ -0,0 +1,115 @@
+import { schema } from 'normalizr';
+import * as api from './api';
+import * as cs from './constant';
+import * as sc from './schema';
+import { CALL_API } from './constant';
+
+
+function createActionTypes(type) {
+  return {
+    get DEFAULT() { return cs[`${type}`] },
+    get REQUEST() { return cs[`${type}_REQUEST`] },
+    get SUCCESS() { return cs[`${type}_SUCCESS`] },
+    get FAIL() { return cs[`${type}_FAIL]`] },
+  }
+}
+
+
+export const petsActionTypes = {
+  
+  listPets: createActionTypes(cs.PETSLIST_PETS),
+  
+  addPet: createActionTypes(cs.PETSADD_PET),
+  
+  getPet: createActionTypes(cs.PETSGET_PET),
+  
+  deletePet: createActionTypes(cs.PETSDELETE_PET),
+  
+};
+
+export const storeActionTypes = {
+  
+  health: createActionTypes(cs.STOREHEALTH),
+  
+};
+
+
+
+export const pets = {
+  
+  /** list the pets */
+  listPets(params:{ 
+    status?: ('available' | 'sold')[],
+    bornAfter?: string,
+    /** @default 20 */
+    limit?: number,
+  }, meta) {
+    return {
+      [CALL_API]: {
+        endpoint: api.pets.listPets(params),
+        types: [cs.PETSLIST_PETS_REQUEST, cs.PETSLIST_PETS_SUCCESS, cs.PETSLIST_PETS_FAIL],
+        
+      },
+      meta: Object.assign({}, meta, { params }),
+    };
+  },
+  
+  addPet(params:{ 
+    pet: api.IPet,
+  }, meta) {
+    return {
+      [CALL_API]: {
+        endpoint: api.pets.addPet(params),
+        types: [cs.PETSADD_PET_REQUEST, cs.PETSADD_PET_SUCCESS, cs.PETSADD_PET_FAIL],
+        
+      },
+      meta: Object.assign({}, meta, { params }),
+    };
+  },
+  
+  getPet(params:{ 
+    petId: number,
+  }, meta) {
+    return {
+      [CALL_API]: {
+        endpoint: api.pets.getPet(params),
+        types: [cs.PETSGET_PET_REQUEST, cs.PETSGET_PET_SUCCESS, cs.PETSGET_PET_FAIL],
+        
+      },
+      meta: Object.assign({}, meta, { params }),
+    };
+  },
+  
+  /** @deprecated */
+  deletePet(params:{ 
+    petId: number,
+  }, meta) {
+    return {
+      [CALL_API]: {
+        endpoint: api.pets.deletePet(params),
+        types: [cs.PETSDELETE_PET_REQUEST, cs.PETSDELETE_PET_SUCCESS, cs.PETSDELETE_PET_FAIL],
+        
+      },
+      meta: Object.assign({}, meta, { params }),
+    };
+  },
+  
+};
+
+export const store = {
+  
+  health(params:{ 
+  }, meta) {
+    return {
+      [CALL_API]: {
+        endpoint: api.store.health(params),
+        types: [cs.STOREHEALTH_REQUEST, cs.STOREHEALTH_SUCCESS, cs.STOREHEALTH_FAIL],
+        
+      },
+      meta: Object.assign({}, meta, { params }),
+    };
+  },
+  
+};
+
+
@@ -0,0 +1,3 @@
+import { schema } from 'normalizr';
+
+
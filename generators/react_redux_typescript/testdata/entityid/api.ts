@@ -0,0 +1,138 @@
+import * as qs from 'qs';
+
+
+export interface IPet { 
+  born_at?: string,
+  readonly id: number,
+  /** @example "rex" */
+  name: string,
+  status?: IStatus,
+  tags?: Record<string, string>,
+}
+
+export enum IStatus { 
+  available = 'available',
+  'it\'s sold' = 'it\'s sold',
+}
+
+
+export const config = {
+  baseUrl: '',
+}
+
+export function myFetch(endpoint, options) {
+  const headers = Object.assign({
+    Accept: 'application/json',
+    'Content-Type': 'application/json',
+  }, options.headers);
+  const body = options.body !== undefined ? JSON.stringify(options.body) : undefined;
+  const opts = Object.assign({}, options, { body, headers });
+  // a 204, the response of a HEAD request and an empty one have no body to parse
+  return fetch(new Request(endpoint, opts)).then(response =>
+    response.text().then(text => {
+      const empty = response.status === 204 || String(opts.method).toUpperCase() === 'HEAD' || !text;
+      const data = empty ? undefined : JSON.parse(text);
+      if (!response.ok) {
+        return Promise.reject(data);
+      }
+
+      return data;
+    })
+  );
+}
+
+export function fetchAPI(url, options) {
+  const endpoint = config.baseUrl + url;
+  return myFetch(endpoint, options);
+}
+
+
+export const pets = {
+  
+  /** list the pets */
+  listPets({ 
+    status,
+    bornAfter,
+    limit,
+  }:{ 
+    status?: ('available' | 'sold')[],
+    bornAfter?: string,
+    /** @default 20 */
+    limit?: number,
+  }):Promise<IPet[]> {
+    const query: { [key: string]: any } = {};
+    const options: { [key: string]: any } = { method: "GET" };
+    
+    query['status'] = status?.join('|')
+    query['born_after'] = bornAfter
+    query['limit'] = limit
+    const QueryString = qs.stringify(query, { skipNulls: true, arrayFormat: 'repeat' });
+    return fetchAPI(`/pets${QueryString ? '?'+QueryString : ''}`, options)
+  },
+  
+  addPet({ 
+    pet,
+  }:{ 
+    pet: IPet,
+  }):Promise<IPet> {
+    const query: { [key: string]: any } = {};
+    const options: { [key: string]: any } = { method: "POST" };
+    
+    if (pet === undefined || pet === null) {
+      throw new Error('pet is required');
+    }
+    options.body = pet
+    const QueryString = qs.stringify(query, { skipNulls: true, arrayFormat: 'repeat' });
+    return fetchAPI(`/pets${QueryString ? '?'+QueryString : ''}`, options)
+  },
+  
+  getPet({ 
+    petId,
+  }:{ 
+    petId: number,
+  }):Promise<IPet> {
+    const query: { [key: string]: any } = {};
+    const options: { [key: string]: any } = { method: "GET" };
+    
+    if (petId === undefined || petId === null) {
+      throw new Error('petId is required');
+    }
+    const QueryString = qs.stringify(query, { skipNulls: true, arrayFormat: 'repeat' });
+    return fetchAPI(`/pets/${encodeURIComponent(String(petId))}${QueryString ? '?'+QueryString : ''}`, options)
+  },
+  
+  /** @deprecated */
+  deletePet({ 
+    petId,
+  }:{ 
+    petId: number,
+  }):Promise<void> {
+    const query: { [key: string]: any } = {};
+    const options: { [key: string]: any } = { method: "DELETE" };
+    
+    if (petId === undefined || petId === null) {
+      throw new Error('petId is required');
+    }
+    const QueryString = qs.stringify(query, { skipNulls: true, arrayFormat: 'repeat' });
+    return fetchAPI(`/pets/${encodeURIComponent(String(petId))}${QueryString ? '?'+QueryString : ''}`, options)
+  },
+  
+};
+
+export const store = {
+  
+  health({ 
+  }:{ 
+  }):Promise<void> {
+    const query: { [key: string]: any } = {};
+    const options: { [key: string]: any } = { method: "GET" };
+    
+    const QueryString = qs.stringify(query, { skipNulls: true, arrayFormat: 'repeat' });
+    return fetchAPI(`/health${QueryString ? '?'+QueryString : ''}`, options)
+  },
+  
+};
+
+
+// swagen:begin custom
+// swagen:end
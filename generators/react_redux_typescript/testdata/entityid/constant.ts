@@ -0,0 +1,27 @@
+export const CALL_API = 'CALL_API';
+
+
+export const STOREHEALTH = 'STOREHEALTH';
+export const STOREHEALTH_REQUEST = 'STOREHEALTH_REQUEST';
+export const STOREHEALTH_SUCCESS = 'STOREHEALTH_SUCCESS';
+export const STOREHEALTH_FAIL = 'STOREHEALTH_FAIL';
+
+export const PETSLIST_PETS = 'PETSLIST_PETS';
+export const PETSLIST_PETS_REQUEST = 'PETSLIST_PETS_REQUEST';
+export const PETSLIST_PETS_SUCCESS = 'PETSLIST_PETS_SUCCESS';
+export const PETSLIST_PETS_FAIL = 'PETSLIST_PETS_FAIL';
+
+export const PETSADD_PET = 'PETSADD_PET';
+export const PETSADD_PET_REQUEST = 'PETSADD_PET_REQUEST';
+export const PETSADD_PET_SUCCESS = 'PETSADD_PET_SUCCESS';
+export const PETSADD_PET_FAIL = 'PETSADD_PET_FAIL';
+
+export const PETSGET_PET = 'PETSGET_PET';
+export const PETSGET_PET_REQUEST = 'PETSGET_PET_REQUEST';
+export const PETSGET_PET_SUCCESS = 'PETSGET_PET_SUCCESS';
+export const PETSGET_PET_FAIL = 'PETSGET_PET_FAIL';
+
+export const PETSDELETE_PET = 'PETSDELETE_PET';
+export const PETSDELETE_PET_REQUEST = 'PETSDELETE_PET_REQUEST';
+export const PETSDELETE_PET_SUCCESS = 'PETSDELETE_PET_SUCCESS';
+export const PETSDELETE_PET_FAIL = 'PETSDELETE_PET_FAIL';
@@ -0,0 +1,6 @@
+import { schema } from 'normalizr';
+
+
+
+export const pet = new schema.Entity('pets', { 
+});
@@ -1,79 +1,304 @@
 package generators
 
 import (
+	"bytes"
 	"fmt"
-	"log"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
 	"strings"
 	"text/template"
+	"text/template/parse"
 
 	"io"
 
 	"github.com/go-openapi/swag"
+	"github.com/xreception/go-swagen/utils"
 )
 
-var templates *Repository
-
 // NewRepository creates a new template repository with the provided functions defined
 func NewRepository(funcs template.FuncMap) *Repository {
 	repo := Repository{
-		files:     make(map[string]string),
-		templates: make(map[string]*template.Template),
-		funcs:     funcs,
+		files: make(map[string]string),
+		funcs: funcs,
 	}
 
 	if repo.funcs == nil {
 		repo.funcs = make(template.FuncMap)
 	}
+	repo.root = template.New("").Funcs(repo.funcMap())
 
 	return &repo
 }
 
 // Repository is the repository for the generator templates.
+// All templates share one set, so that any template could call the ones defined in other files,
+// and a later file could redefine a template of an earlier one.
 type Repository struct {
-	files     map[string]string
-	templates map[string]*template.Template
-	funcs     template.FuncMap
+	files   map[string]string
+	sources []sourceFile
+	root    *template.Template
+	funcs   template.FuncMap
+}
+
+// sourceFile is a loaded file
+type sourceFile struct {
+	name string
+	data []byte
 }
 
-// LoadAssets will load the templates
-func (t *Repository) LoadAssets(assets map[string][]byte) {
-	for name, asset := range assets {
-		if err := t.addFile(name, string(asset)); err != nil {
-			log.Fatal(err)
+// LoadFS will load the .tmpl files of dir in fsys, e.g. the templates embedded into a generator
+func (t *Repository) LoadFS(fsys fs.FS, dir string) error {
+	paths, err := fs.Glob(fsys, path.Join(dir, "*.tmpl"))
+	if err != nil {
+		return err
+	}
+
+	for _, name := range paths {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		if err := t.addFile(path.Base(name), path.Base(name), data); err != nil {
+			return err
 		}
 	}
+	return nil
+}
+
+// LoadDir layers the .tmpl files of dir over the loaded templates.
+// A file replaces the template with the same name, e.g. service.tmpl replaces service,
+// and its {{define}} blocks add new templates or replace the ones with the same name.
+func (t *Repository) LoadDir(dir string) error {
+	if fi, err := os.Stat(dir); err != nil || !fi.IsDir() {
+		return fmt.Errorf("template folder %s does not exist", dir)
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("no .tmpl file in %s", dir)
+	}
+
+	sort.Strings(paths)
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := t.addFile(filepath.Base(path), path, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Files returns the content of the loaded files by file name, a later file replaces an earlier one with the same name
+func (t *Repository) Files() map[string][]byte {
+	files := make(map[string][]byte, len(t.sources))
+	for _, src := range t.sources {
+		files[src.name] = src.data
+	}
+	return files
+}
+
+// Clone returns a copy of the repository, templates loaded into the copy do not change the original
+func (t *Repository) Clone() (*Repository, error) {
+	root, err := t.root.Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]string, len(t.files))
+	for name, file := range t.files {
+		files[name] = file
+	}
+	sources := append([]sourceFile(nil), t.sources...)
+	repo := &Repository{files: files, sources: sources, root: root, funcs: t.funcs}
+	// include of the copy executes the templates of the copy
+	repo.root.Funcs(repo.funcMap())
+	return repo, nil
+}
+
+// Funcs returns a copy of the repository where funcs replace the functions with the same name,
+// e.g. to bind the functions to the options of a generator
+func (t *Repository) Funcs(funcs template.FuncMap) (*Repository, error) {
+	repo, err := t.Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	repo.funcs = make(template.FuncMap, len(t.funcs)+len(funcs))
+	for name, fn := range t.funcs {
+		repo.funcs[name] = fn
+	}
+	for name, fn := range funcs {
+		repo.funcs[name] = fn
+	}
+	repo.root.Funcs(repo.funcMap())
+	return repo, nil
+}
+
+// funcMap returns the functions of the repository with include added.
+// include executes a template and returns the result, so that it could be piped, e.g. {{ include "jsdoc" . | trim }}
+func (t *Repository) funcMap() template.FuncMap {
+	funcs := make(template.FuncMap, len(t.funcs)+1)
+	for name, fn := range t.funcs {
+		funcs[name] = fn
+	}
+	funcs["include"] = t.include
+	return funcs
 }
 
-func (t *Repository) addFile(name, data string) error {
-	fileName := name
-	name = swag.ToJSONName(strings.TrimSuffix(name, ".tmpl"))
+func (t *Repository) include(name string, data interface{}) (string, error) {
+	var buf bytes.Buffer
+	err := t.ExecuteTemplate(&buf, name, data)
+	return buf.String(), err
+}
 
-	templ, err := template.New(name).Funcs(t.funcs).Parse(data)
+// Override returns repo with the templates of dir layered over it, or repo itself if dir is empty.
+// The templates of repo are left untouched.
+func Override(repo *Repository, dir string) (*Repository, error) {
+	if dir == "" {
+		return repo, nil
+	}
 
+	repo, err := repo.Clone()
+	if err != nil {
+		return nil, err
+	}
+	if err := repo.LoadDir(dir); err != nil {
+		return nil, err
+	}
+	return repo, nil
+}
+
+// TemplateName returns the name of the template defined by the body of a file, e.g. service for service.tmpl
+func TemplateName(file string) string {
+	return swag.ToJSONName(strings.TrimSuffix(filepath.Base(file), ".tmpl"))
+}
+
+// addFile parses the file into the set, source tells where the file comes from
+func (t *Repository) addFile(file, source string, content []byte) error {
+	name := TemplateName(file)
+	data := string(content)
+
+	// parse alone first to know which templates the file defines
+	templ, err := template.New(name).Funcs(t.funcMap()).Parse(data)
 	if err != nil {
 		return fmt.Errorf("Failed to load template %s: %v", name, err)
 	}
 
+	if _, err := t.root.New(name).Parse(data); err != nil {
+		return fmt.Errorf("Failed to load template %s: %v", name, err)
+	}
+
 	// Add each defined tempalte into the cache
 	for _, template := range templ.Templates() {
-
-		t.files[template.Name()] = fileName
-		t.templates[template.Name()] = template.Lookup(template.Name())
+		if template.Tree == nil || (template.Name() == name && isEmpty(template) && t.files[name] != "") {
+			// an empty file body does not replace the template with the same name
+			continue
+		}
+		t.files[template.Name()] = source
 	}
+	t.sources = append(t.sources, sourceFile{file, content})
 
 	return nil
 }
 
+func isEmpty(tmpl *template.Template) bool {
+	return tmpl.Tree == nil || tmpl.Tree.Root == nil || len(strings.TrimSpace(tmpl.Tree.Root.String())) == 0
+}
+
 // DumpTemplates prints out a dump of all the defined templates, where they are defined and what their dependencies are.
 func (t *Repository) DumpTemplates() {
 	fmt.Println("# Templates")
-	for name := range t.templates {
+	for _, name := range utils.SortedStringKeys(t.files) {
 		fmt.Printf("## %s defined in `%s`\n", name, t.files[name])
+		if deps := t.Dependencies(name); len(deps) != 0 {
+			fmt.Printf("depends on: %s\n", strings.Join(deps, ", "))
+		}
 	}
 }
 
+// Dependencies returns the sorted names of the templates called or included by the template
+func (t *Repository) Dependencies(name string) []string {
+	tmpl := t.root.Lookup(name)
+	if tmpl == nil || tmpl.Tree == nil {
+		return nil
+	}
+
+	deps := make(map[string]bool)
+	walk(tmpl.Tree.Root, deps)
+	return utils.SortedStringKeys(deps)
+}
+
+// walk collects the names of the templates used by the node
+func walk(node parse.Node, deps map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			walk(child, deps)
+		}
+	case *parse.TemplateNode:
+		deps[n.Name] = true
+	case *parse.ActionNode:
+		walk(n.Pipe, deps)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			// {{ include "name" . }}
+			if len(cmd.Args) > 1 && cmd.Args[0].String() == "include" {
+				if s, ok := cmd.Args[1].(*parse.StringNode); ok {
+					deps[s.Text] = true
+				}
+			}
+			for _, arg := range cmd.Args {
+				walk(arg, deps)
+			}
+		}
+	case *parse.IfNode:
+		walk(&n.BranchNode, deps)
+	case *parse.RangeNode:
+		walk(&n.BranchNode, deps)
+	case *parse.WithNode:
+		walk(&n.BranchNode, deps)
+	case *parse.BranchNode:
+		walk(n.Pipe, deps)
+		walk(n.List, deps)
+		walk(n.ElseList, deps)
+	}
+}
+
+// Names returns the sorted names of the defined templates
+func (t *Repository) Names() []string {
+	return utils.SortedStringKeys(t.files)
+}
+
 // ExecuteTemplate generates file with template
 func (t *Repository) ExecuteTemplate(wr io.Writer, name string, data interface{}) error {
-	tmpl := t.templates[name]
+	tmpl := t.root.Lookup(name)
+	if tmpl == nil || tmpl.Tree == nil {
+		return MissingTemplateError{name, t.Names()}
+	}
 	return tmpl.Execute(wr, data)
 }
+
+// MissingTemplateError records an attempt to execute a template which is not defined.
+type MissingTemplateError struct {
+	Name    string
+	Defined []string
+}
+
+func (err MissingTemplateError) Error() string {
+	return fmt.Sprintf("Template not defined: %s, defined templates are %s", err.Name, strings.Join(err.Defined, ", "))
+}
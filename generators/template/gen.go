@@ -0,0 +1,213 @@
+package template
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/go-openapi/loads"
+	"github.com/go-openapi/spec"
+	"github.com/xreception/go-swagen/factory"
+	"github.com/xreception/go-swagen/generators"
+	"github.com/xreception/go-swagen/model"
+	"github.com/xreception/go-swagen/output"
+	"github.com/xreception/go-swagen/utils"
+)
+
+const generatorName = "template"
+
+// Placeholders of output paths, a template whose path has one is rendered once per tag or schema
+const (
+	TagPlaceholder    = "{tag}"
+	SchemaPlaceholder = "{schema}"
+)
+
+var placeholder = regexp.MustCompile(`{\w+}`)
+
+// FuncMap is a map with default functions for use n the templates.
+// These are available in every template
+var FuncMap template.FuncMap = map[string]interface{}{
+	"CamelCase":      utils.CamelCase,
+	"InterfaceCase":  utils.InterfaceCase,
+	"PluralCase":     utils.PluralCase,
+	"UpperSnakeCase": utils.UpperSnakeCase,
+	"lower":          strings.ToLower,
+	"upper":          strings.ToUpper,
+	"join":           strings.Join,
+	"json":           generators.ToJSON,
+}
+
+func init() {
+	factory.Register(generatorName, &theFactory{})
+}
+
+// Data is passed to the templates, see package model for the fields of the model
+type Data struct {
+	*model.Model
+	// Tag is set when the output path has the {tag} placeholder
+	Tag *model.Tag
+	// Schema is set when the output path has the {schema} placeholder
+	Schema *model.Schema
+}
+
+// theFactory implements factory.IFactory
+type theFactory struct{}
+
+func (f *theFactory) Info() factory.Info {
+	return factory.Info{
+		Description: "renders a folder of templates with the data model of the spec",
+		Features: []string{
+			"definitions, operations grouped by tag, parameters and responses",
+			"{tag} and {schema} placeholders in output paths",
+		},
+		Outputs: []string{"one file per template, tag or schema"},
+	}
+}
+
+func (f *theFactory) Options() factory.Options {
+	return factory.Options{
+		{
+			Name:        "templates",
+			Type:        factory.TypeString,
+			Default:     "",
+			Description: "folder of .tmpl files to render, files starting with _ only define partials",
+		},
+		{
+			Name:        "outputs",
+			Type:        factory.TypeString,
+			Default:     "",
+			Description: "comma separated template=path pairs, a template is written to its file name without .tmpl by default",
+		},
+	}
+}
+
+func (f *theFactory) Create(parameters map[string]interface{}) (factory.IGenerator, error) {
+	dir := parameters["templates"].(string)
+	if len(dir) == 0 {
+		return nil, errors.New("must have a folder of templates, plz use -O templates=<folder>")
+	}
+
+	repo := generators.NewRepository(FuncMap)
+	if err := repo.LoadDir(dir); err != nil {
+		return nil, err
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	paths := make(map[string]string)
+	for _, file := range files {
+		file = filepath.Base(file)
+		if !strings.HasPrefix(file, "_") {
+			paths[file] = strings.TrimSuffix(file, ".tmpl")
+		}
+	}
+	if err := parseOutputs(parameters["outputs"].(string), paths); err != nil {
+		return nil, err
+	}
+
+	gen := &generator{templates: repo}
+	for _, file := range utils.SortedStringKeys(paths) {
+		path := paths[file]
+		if strings.Contains(path, TagPlaceholder) && strings.Contains(path, SchemaPlaceholder) {
+			return nil, fmt.Errorf("output path %s of %s has both %s and %s", path, file, TagPlaceholder, SchemaPlaceholder)
+		}
+		for _, p := range placeholder.FindAllString(path, -1) {
+			if p != TagPlaceholder && p != SchemaPlaceholder {
+				return nil, fmt.Errorf("output path %s of %s has an unknown placeholder %s, plz use %s or %s", path, file, p, TagPlaceholder, SchemaPlaceholder)
+			}
+		}
+		gen.targets = append(gen.targets, target{generators.TemplateName(file), path})
+	}
+	return gen, nil
+}
+
+// parseOutputs sets the output paths given as template=path pairs
+func parseOutputs(outputs string, paths map[string]string) error {
+	for _, pair := range strings.Split(outputs, ",") {
+		if len(strings.TrimSpace(pair)) == 0 {
+			continue
+		}
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || len(strings.TrimSpace(kv[1])) == 0 {
+			return fmt.Errorf("output %q should be in template=path format", pair)
+		}
+		file := strings.TrimSpace(kv[0])
+		if !strings.HasSuffix(file, ".tmpl") {
+			file += ".tmpl"
+		}
+		if _, ok := paths[file]; !ok {
+			return fmt.Errorf("output %q is for an unknown template %s", pair, file)
+		}
+		paths[file] = strings.TrimSpace(kv[1])
+	}
+	return nil
+}
+
+// target is a template and the pattern of its output path
+type target struct {
+	template string
+	path     string
+}
+
+// generator implements factory.IGenerator
+type generator struct {
+	templates *generators.Repository
+	targets   []target
+}
+
+// Parse implements IGenerator's Parse method.
+func (gen *generator) Parse(swagger *spec.Swagger, out output.Output) error {
+	m := model.New(swagger)
+
+	for _, t := range gen.targets {
+		switch {
+		case strings.Contains(t.path, TagPlaceholder):
+			for _, tag := range m.Tags {
+				path := strings.Replace(t.path, TagPlaceholder, tag.Name, -1)
+				if err := gen.writeTemplate(out, path, t.template, Data{Model: m, Tag: tag}); err != nil {
+					return err
+				}
+			}
+		case strings.Contains(t.path, SchemaPlaceholder):
+			for _, schema := range m.Schemas {
+				path := strings.Replace(t.path, SchemaPlaceholder, schema.Name, -1)
+				if err := gen.writeTemplate(out, path, t.template, Data{Model: m, Schema: schema}); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := gen.writeTemplate(out, t.path, t.template, Data{Model: m}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ParseFile implements IGenerator's ParseFile method
+func (gen *generator) ParseFile(in string, out output.Output) error {
+	doc, err := loads.Spec(in)
+	if err != nil {
+		return err
+	}
+
+	return gen.Parse(doc.Spec(), out)
+}
+
+// writeTemplate renders the template and writes the result to file name of out
+func (gen *generator) writeTemplate(out output.Output, name string, template string, data interface{}) error {
+	var buf bytes.Buffer
+	if err := gen.templates.ExecuteTemplate(&buf, template, data); err != nil {
+		return err
+	}
+	return out.WriteFile(name, buf.Bytes())
+}
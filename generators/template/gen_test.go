@@ -0,0 +1,180 @@
+package template
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xreception/go-swagen/generators"
+	"github.com/xreception/go-swagen/output"
+)
+
+// petstore is the swagger rendered by the tests of every generator
+const petstore = "../testdata/petstore.json"
+
+// create writes the templates into a temporary folder and creates a generator rendering them
+func create(t *testing.T, templates map[string]string, outputs string) (*generator, error) {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range templates {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	f := &theFactory{}
+	parameters, err := f.Options().Validate(map[string]interface{}{"templates": dir, "outputs": outputs})
+	if err != nil {
+		t.Fatal(err)
+	}
+	gen, err := f.Create(parameters)
+	if err != nil {
+		return nil, err
+	}
+	return gen.(*generator), nil
+}
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name      string
+		templates map[string]string
+		outputs   string
+		want      map[string]string
+	}{
+		{
+			name:      "file name without .tmpl",
+			templates: map[string]string{"README.md.tmpl": "# {{ .Title }} {{ .Version }}"},
+			want:      map[string]string{"README.md": "# petstore 1.2.0"},
+		},
+		{
+			name: "tag placeholder",
+			templates: map[string]string{
+				"service.tmpl": "{{ .Tag.Name }}:{{ range .Tag.Operations }} {{ .ID }}{{ end }}",
+			},
+			outputs: "service=services/{tag}.md",
+			want: map[string]string{
+				"services/pets.md":  "pets: listPets addPet getPet deletePet",
+				"services/store.md": "store: health",
+			},
+		},
+		{
+			name:      "schema placeholder",
+			templates: map[string]string{"model.tmpl": "{{ .Schema.Name | CamelCase }}"},
+			outputs:   "model.tmpl = models/{schema}.ts",
+			want: map[string]string{
+				"models/Error.ts":  "error",
+				"models/Pet.ts":    "pet",
+				"models/Status.ts": "status",
+			},
+		},
+		{
+			name: "partials",
+			templates: map[string]string{
+				"_helpers.tmpl": `{{ define "title" }}{{ .Title | upper }}{{ end }}`,
+				"index.tmpl":    `{{ template "title" . }} {{ include "title" . | lower }}`,
+			},
+			want: map[string]string{"index": "PETSTORE petstore"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen, err := create(t, tt.templates, tt.outputs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			mem := output.NewMemory()
+			if err := gen.ParseFile(petstore, mem); err != nil {
+				t.Fatal(err)
+			}
+			got := make(map[string]string)
+			for _, name := range mem.Names() {
+				data, _ := mem.File(name)
+				got[name] = string(data)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("files = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		templates map[string]string
+		outputs   string
+		err       string
+	}{
+		{
+			name:      "both placeholders",
+			templates: map[string]string{"a.tmpl": ""},
+			outputs:   "a={tag}/{schema}.ts",
+			err:       "output path {tag}/{schema}.ts of a.tmpl has both {tag} and {schema}",
+		},
+		{
+			name:      "unknown placeholder",
+			templates: map[string]string{"a.tmpl": ""},
+			outputs:   "a={tags}.ts",
+			err:       "output path {tags}.ts of a.tmpl has an unknown placeholder {tags}, plz use {tag} or {schema}",
+		},
+		{
+			name:      "unknown template",
+			templates: map[string]string{"a.tmpl": ""},
+			outputs:   "b=b.ts",
+			err:       `output "b=b.ts" is for an unknown template b.tmpl`,
+		},
+		{
+			name:      "partial as output",
+			templates: map[string]string{"a.tmpl": "", "_b.tmpl": ""},
+			outputs:   "_b=b.ts",
+			err:       "unknown template _b.tmpl",
+		},
+		{
+			name:      "no path",
+			templates: map[string]string{"a.tmpl": ""},
+			outputs:   "a=",
+			err:       `output "a=" should be in template=path format`,
+		},
+		{
+			name:      "no template",
+			templates: map[string]string{"a.txt": ""},
+			err:       "no .tmpl file in",
+		},
+		{
+			name:      "invalid template",
+			templates: map[string]string{"a.tmpl": "{{ .Title "},
+			err:       "Failed to load template a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := create(t, tt.templates, tt.outputs)
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("Create() error = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestGenerateMissingTemplate(t *testing.T) {
+	gen, err := create(t, map[string]string{
+		"_helpers.tmpl": `{{ define "title" }}{{ .Title }}{{ end }}`,
+		"index.tmpl":    `{{ include "titel" . }}`,
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = gen.ParseFile(petstore, output.NewMemory())
+	var missing generators.MissingTemplateError
+	if !errors.As(err, &missing) {
+		t.Fatalf("ParseFile() error = %v, want a MissingTemplateError", err)
+	}
+	if missing.Name != "titel" || !reflect.DeepEqual(missing.Defined, []string{"helpers", "index", "title"}) {
+		t.Errorf("MissingTemplateError = %+v, want titel missing in helpers, index and title", missing)
+	}
+}
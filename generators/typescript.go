@@ -0,0 +1,89 @@
+package generators
+
+import (
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"text/template"
+
+	"github.com/xreception/go-swagen/model"
+	"github.com/xreception/go-swagen/utils"
+)
+
+// typeScriptAssets are the templates shared by the typescript generators, i.e. the codec runtime convert.ts
+//
+//go:embed templates/*.tmpl
+var typeScriptAssets embed.FS
+
+// TypeScriptFuncMap has the functions of the typescript generators which do not depend on their options
+var TypeScriptFuncMap template.FuncMap = map[string]interface{}{
+	"CamelCase":      utils.CamelCase,
+	"InterfaceCase":  utils.InterfaceCase,
+	"PluralCase":     utils.PluralCase,
+	"UpperSnakeCase": utils.UpperSnakeCase,
+	"isInterface":    model.TypeScript{}.IsInterface,
+	"enumMember":     model.TypeScript{}.EnumMember,
+	"literal":        model.TypeScript{}.Literal,
+	"jsdoc":          model.TypeScript{}.JSDoc,
+	"json":           ToJSON,
+}
+
+// TypeScriptFuncs returns the functions which depend on the options of a generator and the identifiers of the model,
+// qualifiedType refers to the definitions in namespace, e.g. schemas.
+func TypeScriptFuncs(ts model.TypeScript, namespace string) template.FuncMap {
+	qualified := ts
+	qualified.Namespace = namespace
+	return template.FuncMap{
+		"schemaType":     ts.Type,
+		"qualifiedType":  qualified.Type,
+		"property":       ts.Property,
+		"serviceName":    ts.ServiceName,
+		"instanceName":   ts.InstanceName,
+		"moduleName":     ts.ModuleName,
+		"definitionName": ts.DefinitionName,
+		"operationName":  ts.OperationName,
+		"errorName":      ts.ErrorName,
+		"parameterName":  ts.ParameterName,
+		"propertyName":   ts.PropertyName,
+	}
+}
+
+// CodecFuncs returns the functions converting values of runtime types and renaming properties, they depend on the model
+func CodecFuncs(c *model.Codecs) template.FuncMap {
+	return template.FuncMap{
+		"converts":  c.Enabled,
+		"codecs":    c.Definitions,
+		"codec":     c.Codec,
+		"encode":    c.Encode,
+		"decode":    c.Decode,
+		"serialize": c.Serialize,
+		"path":      c.Path,
+	}
+}
+
+// NewTypeScriptRepository creates a repository with the typescript functions and the shared templates,
+// and loads the .tmpl files of dir in fsys over them, e.g. the templates embedded into a generator
+func NewTypeScriptRepository(namespace string, fsys fs.FS, dir string) (*Repository, error) {
+	funcs := TypeScriptFuncs(model.TypeScript{}, namespace)
+	for name, fn := range CodecFuncs(model.TypeScript{}.Codecs(&model.Model{})) {
+		funcs[name] = fn
+	}
+	for name, fn := range TypeScriptFuncMap {
+		funcs[name] = fn
+	}
+
+	repo := NewRepository(funcs)
+	if err := repo.LoadFS(typeScriptAssets, "templates"); err != nil {
+		return nil, err
+	}
+	if err := repo.LoadFS(fsys, dir); err != nil {
+		return nil, err
+	}
+	return repo, nil
+}
+
+// ToJSON returns the value as json, e.g. a quoted string
+func ToJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	return string(b), err
+}
@@ -1,95 +1,200 @@
 package typescript
 
 import (
+	"bytes"
+	"embed"
 	"errors"
 	"fmt"
-	"os"
-	"regexp"
-	"text/template"
+	"log"
+	"strings"
 
 	"github.com/go-openapi/loads"
 	"github.com/go-openapi/spec"
 	"github.com/xreception/go-swagen/factory"
 	"github.com/xreception/go-swagen/generators"
+	"github.com/xreception/go-swagen/model"
+	"github.com/xreception/go-swagen/output"
 	"github.com/xreception/go-swagen/utils"
 )
 
 const generatorName = "typescript"
-const entityID = "uri"
 
-var templates *generators.Repository
-var assets = map[string][]byte{
-	"schema.tmpl":  MustAsset("templates/schema.tmpl"),
-	"service.tmpl": MustAsset("templates/service.tmpl"),
-	"request.tmpl": MustAsset("templates/request.tmpl"),
-}
+// namespace is the import of schema.ts in the services
+const namespace = "schemas."
 
-// FuncMap is a map with default functions for use n the templates.
-// These are available in every template
-var FuncMap template.FuncMap = map[string]interface{}{
-	"CamelCase":      utils.CamelCase,
-	"InterfaceCase":  utils.InterfaceCase,
-	"PluralCase":     utils.PluralCase,
-	"UpperSnakeCase": utils.UpperSnakeCase,
-	"schemaType":     schemaType,
-	"schemaRef":      schemaRef,
-	"parameterType":  parameterType,
-	"responseType":   responseType,
+// runtimes are the templates of the runtimes by name
+var runtimes = map[string]string{
+	"fetch":     "fetch",
+	"axios":     "axios",
+	"node-http": "nodeHttp",
 }
 
+var templates *generators.Repository
+
+//go:embed templates/*.tmpl
+var assets embed.FS
+
 func init() {
 	factory.Register(generatorName, &theFactory{})
-	templates = generators.NewRepository(FuncMap)
-	templates.LoadAssets(assets)
+	var err error
+	templates, err = generators.NewTypeScriptRepository(namespace, assets, "templates")
+	if err != nil {
+		log.Fatal(err)
+	}
 }
 
 // theFactory implements factory.IFactory
 type theFactory struct{}
 
+func (f *theFactory) Info() factory.Info {
+	return factory.Info{
+		Description: "typescript client with one service class per tag",
+		Features: []string{
+			"definitions as interfaces or type aliases, optional properties unless required",
+			"allOf, additionalProperties, inline objects, enums, x-nullable and readOnly",
+			"enum definitions",
+			"identifiers escaped from reserved words, quoted keys, clashing names numbered in the order of the spec",
+			"operations of every http method",
+			"path, query, header, body and formData parameters, files are uploaded as multipart FormData",
+			"the type of a 2xx response or void, error unions of 4xx, 5xx and default responses",
+			"formats mapped to typescript types, Date and bigint values are converted",
+			"index.ts exporting the services and types, and createServices making every service from one IRequest",
+			"fetch, axios and node-http runtimes with base url, credentials of security definitions, timeouts and AbortSignal",
+			"camel cased properties renamed from and to their json names by a generated key map, or json names kept",
+		},
+		Outputs: []string{"schema.ts", "request.ts", "{tag}.ts, the service name if the tag is no file name or taken", "convert.ts if a format is mapped to Date or bigint, or a property is renamed",
+			"index.ts", "package.json and tsconfig.json if the package option is set", "runtime.ts if the runtime option is set"},
+	}
+}
+
+func (f *theFactory) Templates() *generators.Repository {
+	return templates
+}
+
+func (f *theFactory) Options() factory.Options {
+	return factory.Options{
+		{
+			Name:        "templates",
+			Type:        factory.TypeString,
+			Default:     "",
+			Description: "folder of .tmpl files overriding the built-in templates",
+		},
+		{
+			Name:        "optional",
+			Type:        factory.TypeBool,
+			Default:     false,
+			Description: "make every property optional, for backends omitting zero values like grpc-gateway",
+		},
+		{
+			Name:        "formats",
+			Type:        factory.TypeString,
+			Default:     "",
+			Description: "comma separated format=type pairs, e.g. int64=string,date-time=Date,binary=Blob",
+		},
+		{
+			Name:        "package",
+			Type:        factory.TypeString,
+			Default:     "",
+			Description: "name of the npm package, package.json and tsconfig.json are written if it is set",
+		},
+		{
+			Name:        "runtime",
+			Type:        factory.TypeString,
+			Default:     "",
+			Description: "write runtime.ts implementing IRequest with fetch, axios or node-http",
+		},
+		{
+			Name:        "wirenames",
+			Type:        factory.TypeBool,
+			Default:     false,
+			Description: "keep the json names of properties instead of camel casing and converting them",
+		},
+	}
+}
+
 func (f *theFactory) Create(parameters map[string]interface{}) (factory.IGenerator, error) {
+	repo, err := generators.Override(templates, parameters["templates"].(string))
+	if err != nil {
+		return nil, err
+	}
+	formats, err := parseFormats(parameters["formats"].(string))
+	if err != nil {
+		return nil, err
+	}
+	runtime := parameters["runtime"].(string)
+	if _, ok := runtimes[runtime]; runtime != "" && !ok {
+		return nil, fmt.Errorf("unknown runtime %s, plz use -O runtime=fetch, axios or node-http", runtime)
+	}
+	ts := model.TypeScript{
+		Optional:  parameters["optional"].(bool),
+		Formats:   formats,
+		WireNames: parameters["wirenames"].(bool),
+	}
+	repo, err = repo.Funcs(generators.TypeScriptFuncs(ts, namespace))
+	if err != nil {
+		return nil, err
+	}
+
 	return &generator{
-		Schemas:  make([]*spec.Schema, 0),
-		Services: make(map[string][]*spec.Operation),
+		templates: repo,
+		ts:        ts,
+		pkg:       parameters["package"].(string),
+		runtime:   runtime,
 	}, nil
 }
 
+// parseFormats parses the format=type pairs of the formats option
+func parseFormats(pairs string) (map[string]string, error) {
+	formats := make(map[string]string)
+	for _, pair := range strings.Split(pairs, ",") {
+		if len(strings.TrimSpace(pair)) == 0 {
+			continue
+		}
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || len(strings.TrimSpace(kv[0])) == 0 || len(strings.TrimSpace(kv[1])) == 0 {
+			return nil, fmt.Errorf("format %q should be in format=type format", pair)
+		}
+		formats[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+	return formats, nil
+}
+
 // generator implements factory.IGenerator
 type generator struct {
 	factory.IGenerator
-	swagger *spec.Swagger
-
-	Schemas  []*spec.Schema
-	Services map[string][]*spec.Operation
+	templates *generators.Repository
+	ts        model.TypeScript
+	codecs    *model.Codecs
+	pkg       string
+	runtime   string
 }
 
 // Parse implements IGenerator's Parse method.
-func (gen *generator) Parse(swagger *spec.Swagger, out string) error {
-	gen.swagger = swagger
-
-	paths := gen.swagger.Paths
-	if paths == nil || paths.Paths == nil || len(paths.Paths) == 0 {
+func (gen *generator) Parse(swagger *spec.Swagger, out output.Output) error {
+	if swagger.Paths == nil || len(swagger.Paths.Paths) == 0 {
 		return errors.New("this swagger has no path")
 	}
 
-	for _, name := range utils.SortedStringKeys(swagger.Definitions) {
-		schema := swagger.Definitions[name]
-		schema.ID = name
-		gen.Schemas = append(gen.Schemas, &schema)
+	m := model.New(swagger)
+	// the identifiers and templates are the ones of this run, a generator could parse again, e.g. in watch
+	run := *gen
+	run.ts.Identifiers = run.ts.Identify(m)
+	run.codecs = run.ts.Codecs(m)
+	funcs := generators.TypeScriptFuncs(run.ts, namespace)
+	for name, fn := range generators.CodecFuncs(run.codecs) {
+		funcs[name] = fn
 	}
-
-	for _, endpoint := range utils.SortedStringKeys(paths.Paths) {
-		item := paths.Paths[endpoint]
-		gen.parseOperation(endpoint, "GET", item.Get)
-		gen.parseOperation(endpoint, "PUT", item.Put)
-		gen.parseOperation(endpoint, "POST", item.Post)
-		gen.parseOperation(endpoint, "DELETE", item.Delete)
+	templates, err := gen.templates.Funcs(funcs)
+	if err != nil {
+		return err
 	}
+	run.templates = templates
 
-	return gen.write(out)
+	return run.write(m, out)
 }
 
 // ParseFile implements IGenerator's ParseFile method
-func (gen *generator) ParseFile(in string, out string) error {
+func (gen *generator) ParseFile(in string, out output.Output) error {
 	doc, err := loads.Spec(in)
 	if err != nil {
 		return err
@@ -98,105 +203,153 @@ func (gen *generator) ParseFile(in string, out string) error {
 	return gen.Parse(doc.Spec(), out)
 }
 
-func (gen *generator) write(folder string) error {
-	err := gen.writeAPI(folder)
+func (gen *generator) write(m *model.Model, out output.Output) error {
+	services, err := gen.writeAPI(m, out)
 	if err != nil {
 		return err
 	}
 
-	err = gen.writeSchema(folder)
+	err = gen.writeSchema(m, out)
 	if err != nil {
 		return err
 	}
 
-	return gen.writeRequest(folder)
-}
+	if gen.codecs.Enabled() {
+		err = gen.writeTemplate(out, "convert.ts", "convert", m)
+		if err != nil {
+			return err
+		}
+	}
+
+	if gen.runtime != "" {
+		err = gen.writeTemplate(out, "runtime.ts", runtimes[gen.runtime], struct {
+			*model.Model
+			BaseURL string
+		}{m, baseURL(m)})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = gen.writeTemplate(out, "index.ts", "index", struct {
+		Services []service
+		Runtime  string
+	}{services, gen.runtime})
+	if err != nil {
+		return err
+	}
 
-func (gen *generator) writeAPI(folder string) error {
-	for service, operations := range gen.Services {
-		filePath := fmt.Sprintf("%s/%s.ts", folder, service)
-		file, err := os.Create(filePath)
-		defer file.Close()
+	if gen.pkg != "" {
+		err = gen.writePackage(m, out)
 		if err != nil {
 			return err
 		}
+	}
+
+	return gen.writeRequest(m, out)
+}
+
+// service is a service class written to the file of its tag
+type service struct {
+	Tag string
+	// Errors are the operations whose error union is exported by this service, not by an earlier one
+	Errors []*model.Operation
+}
+
+// writeAPI writes a service class per tag, it returns the services written
+func (gen *generator) writeAPI(m *model.Model, out output.Output) ([]service, error) {
+	var services []service
+	exported := make(map[*model.Operation]bool)
+	for _, tag := range m.Tags {
+		operations := supported(tag.Operations)
+		if len(operations) == 0 {
+			continue
+		}
 
-		err = templates.ExecuteTemplate(file, "service", struct {
+		err := gen.writeTemplate(out, gen.ts.ModuleName(tag.Name)+".ts", "service", struct {
 			Service    string
-			Operations []*spec.Operation
+			Tag        string
+			Operations []*model.Operation
 		}{
-			service,
+			gen.ts.ServiceName(tag.Name),
+			tag.Name,
 			operations,
 		})
 
 		if err != nil {
-			return err
+			return nil, err
+		}
+		s := service{Tag: tag.Name}
+		for _, op := range operations {
+			if len(op.Errors()) != 0 && !exported[op] {
+				exported[op] = true
+				s.Errors = append(s.Errors, op)
+			}
 		}
+		services = append(services, s)
 	}
 
-	return nil
+	return services, nil
 }
 
-func (gen *generator) writeSchema(folder string) error {
-	filePath := fmt.Sprintf("%s/%s.ts", folder, "schema")
-	file, err := os.Create(filePath)
-	defer file.Close()
+// writePackage writes package.json and tsconfig.json, so that the output is an npm package
+func (gen *generator) writePackage(m *model.Model, out output.Output) error {
+	version := m.Version
+	if version == "" {
+		version = "0.0.0"
+	}
+	data := struct {
+		*model.Model
+		Package        string
+		PackageVersion string
+		Runtime        string
+	}{m, gen.pkg, version, gen.runtime}
+
+	err := gen.writeTemplate(out, "package.json", "package", data)
 	if err != nil {
 		return err
 	}
-
-	return templates.ExecuteTemplate(file, "schema", gen)
+	return gen.writeTemplate(out, "tsconfig.json", "tsconfig", data)
 }
 
-func (gen *generator) writeRequest(folder string) error {
-	filePath := fmt.Sprintf("%s/%s.ts", folder, "request")
-	file, err := os.Create(filePath)
-	defer file.Close()
-	if err != nil {
-		return err
-	}
+func (gen *generator) writeSchema(m *model.Model, out output.Output) error {
+	return gen.writeTemplate(out, "schema.ts", "schema", m)
+}
 
-	return templates.ExecuteTemplate(file, "request", gen)
+func (gen *generator) writeRequest(m *model.Model, out output.Output) error {
+	return gen.writeTemplate(out, "request.ts", "request", m)
 }
 
-// parseOperation parse the operation of swagger.
-func (gen *generator) parseOperation(endpoint string, method string, op *spec.Operation) {
-	if op == nil {
-		return
+// writeTemplate renders the template and writes the result to file name of out
+func (gen *generator) writeTemplate(out output.Output, name string, template string, data interface{}) error {
+	var buf bytes.Buffer
+	if err := gen.templates.ExecuteTemplate(&buf, template, data); err != nil {
+		return err
 	}
+	return out.WriteFile(name, buf.Bytes())
+}
 
-	op.AddExtension("method", method)
-	op.AddExtension("endpoint", replaceWithDollar(endpoint))
-
-	for i := range op.Parameters {
-		gen.parseParam(&op.Parameters[i])
+// baseURL returns the url of the api in the spec, e.g. https://api.example.com/v1, or the base path without host
+func baseURL(m *model.Model) string {
+	if m.Host == "" {
+		return m.BasePath
 	}
-
-	for _, tag := range op.Tags {
-		operations, ok := gen.Services[tag]
-		if !ok {
-			operations = make([]*spec.Operation, 0)
-		}
-
-		operations = append(operations, op)
-		gen.Services[tag] = operations
+	scheme := "https"
+	if len(m.Schemes) != 0 && !utils.Contains(m.Schemes, "https") {
+		scheme = m.Schemes[0]
 	}
+	return scheme + "://" + m.Host + m.BasePath
 }
 
-// parseParam parse the parameters of operation
-func (gen *generator) parseParam(param *spec.Parameter) {
-	if param.Schema != nil {
-		param.Type = utils.InterfaceCase(param.Schema.ID)
-	} else if param.Type == "integer" {
-		param.Type = "number"
+// supported returns the operations which belong to a service class, i.e. which have a tag
+func supported(operations []*model.Operation) []*model.Operation {
+	var result []*model.Operation
+	for _, op := range operations {
+		if len(op.Tags) == 0 {
+			generators.Warn("skip %s %s, it has no tag to be grouped into a service", op.Method, op.Path)
+			continue
+		}
+		result = append(result, op)
 	}
-}
-
-func replaceWithDollar(s string) string {
-	re := regexp.MustCompile(`{[a-z0-9A-Z_-]+}`)
-	return re.ReplaceAllStringFunc(s, func(matched string) string {
-		n := len(matched)
-		w := matched[1 : n-1]
-		return "${" + utils.CamelCase(w) + "}"
-	})
+	return result
 }
@@ -0,0 +1,136 @@
+package typescript
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/xreception/go-swagen/output"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// petstore is the swagger rendered by the golden tests of every generator
+const petstore = "../testdata/petstore.json"
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters map[string]interface{}
+	}{
+		{name: "default"},
+		{name: "wirenames", parameters: map[string]interface{}{"wirenames": true, "optional": true}},
+		{name: "fetch", parameters: map[string]interface{}{
+			"formats": "date=Date,date-time=Date,int64=bigint",
+			"runtime": "fetch",
+			"package": "petstore",
+		}},
+		{name: "axios", parameters: map[string]interface{}{"runtime": "axios"}},
+		{name: "node-http", parameters: map[string]interface{}{"runtime": "node-http"}},
+	}
+
+	f := &theFactory{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parameters, err := f.Options().Validate(tt.parameters)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gen, err := f.Create(parameters)
+			if err != nil {
+				t.Fatal(err)
+			}
+			mem := output.NewMemory()
+			if err := gen.ParseFile(petstore, mem); err != nil {
+				t.Fatal(err)
+			}
+			golden(t, filepath.Join("testdata", tt.name), mem)
+		})
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters map[string]interface{}
+		err        string
+	}{
+		{name: "unknown runtime", parameters: map[string]interface{}{"runtime": "xhr"}, err: "unknown runtime xhr, plz use -O runtime=fetch, axios or node-http"},
+		{name: "bad formats", parameters: map[string]interface{}{"formats": "int64"}, err: `format "int64" should be in format=type format`},
+	}
+
+	f := &theFactory{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parameters, err := f.Options().Validate(tt.parameters)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := f.Create(parameters); err == nil || err.Error() != tt.err {
+				t.Errorf("Create() error = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestGenerateTwice(t *testing.T) {
+	f := &theFactory{}
+	parameters, err := f.Options().Validate(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gen, err := f.Create(parameters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a generator parses again in watch, every run starts clean
+	for i := 0; i < 2; i++ {
+		mem := output.NewMemory()
+		if err := gen.ParseFile(petstore, mem); err != nil {
+			t.Fatal(err)
+		}
+		golden(t, filepath.Join("testdata", "default"), mem)
+	}
+}
+
+// golden compares the files in memory with the ones in dir, or writes them to dir if -update is given
+func golden(t *testing.T, dir string, mem *output.Memory) {
+	t.Helper()
+	if *update {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Fatal(err)
+		}
+		out := output.NewDir(dir)
+		if err := mem.CopyTo(out); err != nil {
+			t.Fatal(err)
+		}
+		if err := out.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var names []string
+	err := filepath.Walk(dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil || fi.IsDir() {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		names = append(names, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(mem.Names(), names) {
+		t.Errorf("files = %v, want %v, run go test -update to accept them", mem.Names(), names)
+	}
+	diff, err := mem.Diff(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != "" {
+		t.Errorf("files differ from %s, run go test -update to accept them:\n%s", dir, diff)
+	}
+}
@@ -0,0 +1,24 @@
+import { IRequest } from './request';
+import pets from './pets';
+import store from './store';
+
+export * from './schema';
+export * from './request';
+export * from './convert';
+export * from './runtime';
+export type { ListPetsError, GetPetError } from './pets';
+export { pets, store };
+
+// Services has an instance of every service
+export interface Services { 
+  pets: pets,
+  store: store,
+}
+
+// createServices makes every service, they send their requests with request
+export function createServices(request: IRequest): Services {
+  return { 
+    pets: new pets(request),
+    store: new store(request),
+  };
+}
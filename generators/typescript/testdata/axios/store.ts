@@ -0,0 +1,25 @@
+import * as schemas from './schema';
+import { IRequest, CallOptions } from './request';
+
+
+export default class store {
+  request: IRequest
+
+  constructor(request: IRequest) {
+    this.request = request;
+  }
+  
+  health({ 
+  }:{ 
+  }, call: CallOptions = {}):Promise<void> {
+    const options: { [key: string]: any } = {
+      method: "GET", query: {}, headers: Object.assign({}, call.headers), signal: call.signal, timeout: call.timeout,
+    };
+    
+    return this.request.send(`/health`, options)
+  }
+  
+
+  // swagen:begin custom
+  // swagen:end
+}
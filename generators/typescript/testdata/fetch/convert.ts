@@ -0,0 +1,76 @@
+// converts json values from and to the runtime types of formats, e.g. date-time strings to Date,
+// and renames the json names of properties to their keys in typescript, e.g. user_id to userId
+
+export type Codec = 'date-time' | 'date' | 'bigint'
+  | { ref: string }
+  | { array: Codec }
+  | { map: Codec }
+  | { object: { [property: string]: Codec }; keys?: { [property: string]: string } }
+  | { all: Codec[] };
+
+// codecs of the definitions with values to convert
+export const codecs: { [definition: string]: Codec } = { 
+  IPet: { object: { born_at: 'date-time', id: 'bigint' }, keys: { born_at: 'bornAt' } },
+};
+
+function convert(codec: Codec, value: any, decoding: boolean): any {
+  if (value === null || value === undefined) {
+    return value;
+  }
+  if (codec === 'date-time' || codec === 'date') {
+    if (decoding) {
+      return new Date(value);
+    }
+    const iso = (value as Date).toISOString();
+    return codec === 'date' ? iso.slice(0, 10) : iso;
+  }
+  if (codec === 'bigint') {
+    return decoding ? BigInt(value) : value.toString();
+  }
+  if ('ref' in codec) {
+    return convert(codecs[codec.ref], value, decoding);
+  }
+  if ('all' in codec) {
+    return codec.all.reduce((v, c) => convert(c, v, decoding), value);
+  }
+  if ('array' in codec) {
+    return (value as any[]).map(item => convert(codec.array, item, decoding));
+  }
+
+  if ('map' in codec) {
+    const result = Object.assign({}, value);
+    Object.keys(result).forEach(key => {
+      result[key] = convert(codec.map, result[key], decoding);
+    });
+    return result;
+  }
+
+  // keys maps json names to typescript keys, encoding needs the reverse
+  const keys = codec.keys || {};
+  const names: { [key: string]: string } = {};
+  Object.keys(keys).forEach(name => {
+    names[keys[name]] = name;
+  });
+  const result: { [key: string]: any } = {};
+  Object.keys(value).forEach(key => {
+    const name = decoding ? key : own(names, key) || key;
+    const property = own(codec.object, name);
+    result[decoding ? own(keys, key) || key : name] = property ? convert(property, value[key], decoding) : value[key];
+  });
+  return result;
+}
+
+// own returns the value of a key of the object, not of its prototype
+function own<T>(object: { [key: string]: T }, key: string): T | undefined {
+  return Object.prototype.hasOwnProperty.call(object, key) ? object[key] : undefined;
+}
+
+// decode converts a json value to runtime types, e.g. date-time strings to Date
+export function decode(codec: Codec, value: any): any {
+  return convert(codec, value, true);
+}
+
+// encode converts a value of runtime types to json, e.g. Date to date-time strings
+export function encode(codec: Codec, value: any): any {
+  return convert(codec, value, false);
+}
@@ -0,0 +1,99 @@
+import * as schemas from './schema';
+import { IRequest, CallOptions, ApiError } from './request';
+import { decode, encode } from './convert';
+
+
+// listPets rejects with ListPetsError if the response is not successful
+export type ListPetsError =
+  | ApiError<number, schemas.IError>;
+
+// getPet rejects with GetPetError if the response is not successful
+export type GetPetError =
+  | ApiError<404, schemas.IError>;
+
+export default class pets {
+  request: IRequest
+
+  constructor(request: IRequest) {
+    this.request = request;
+  }
+  
+  /** list the pets */
+  listPets({ 
+    status,
+    bornAfter,
+    limit,
+  }:{ 
+    status?: ('available' | 'sold')[],
+    bornAfter?: Date,
+    /** @default 20 */
+    limit?: number,
+  }, call: CallOptions = {}):Promise<schemas.IPet[]> {
+    const options: { [key: string]: any } = {
+      method: "GET", query: {}, headers: Object.assign({}, call.headers), signal: call.signal, timeout: call.timeout,
+      security: [["api_key"]],
+    };
+    
+    options.query['status'] = status?.join('|')
+    options.query['born_after'] = encode('date', bornAfter)
+    options.query['limit'] = limit
+    return this.request.send(`/pets`, options)
+      .then(data => decode({ array: { ref: 'IPet' } }, data))
+  }
+  
+  addPet({ 
+    pet,
+  }:{ 
+    pet: schemas.IPet,
+  }, call: CallOptions = {}):Promise<schemas.IPet> {
+    const options: { [key: string]: any } = {
+      method: "POST", query: {}, headers: Object.assign({}, call.headers), signal: call.signal, timeout: call.timeout,
+      security: [["api_key","basic"]],
+    };
+    
+    if (pet === undefined || pet === null) {
+      throw new Error('pet is required');
+    }
+    options.body = encode({ ref: 'IPet' }, pet)
+    return this.request.send(`/pets`, options)
+      .then(data => decode({ ref: 'IPet' }, data))
+  }
+  
+  getPet({ 
+    petId,
+  }:{ 
+    petId: bigint,
+  }, call: CallOptions = {}):Promise<schemas.IPet> {
+    const options: { [key: string]: any } = {
+      method: "GET", query: {}, headers: Object.assign({}, call.headers), signal: call.signal, timeout: call.timeout,
+      security: [["api_key"]],
+    };
+    
+    if (petId === undefined || petId === null) {
+      throw new Error('petId is required');
+    }
+    return this.request.send(`/pets/${encodeURIComponent(String(encode('bigint', petId)))}`, options)
+      .then(data => decode({ ref: 'IPet' }, data))
+  }
+  
+  /** @deprecated */
+  deletePet({ 
+    petId,
+  }:{ 
+    petId: bigint,
+  }, call: CallOptions = {}):Promise<void> {
+    const options: { [key: string]: any } = {
+      method: "DELETE", query: {}, headers: Object.assign({}, call.headers), signal: call.signal, timeout: call.timeout,
+      security: [["api_key"]],
+    };
+    
+    if (petId === undefined || petId === null) {
+      throw new Error('petId is required');
+    }
+    return this.request.send(`/pets/${encodeURIComponent(String(encode('bigint', petId)))}`, options)
+  }
+  
+
+  // swagen:begin custom
+  // swagen:end
+}
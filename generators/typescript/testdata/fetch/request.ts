@@ -0,0 +1,30 @@
+export interface IRequest {
+  // send rejects with an ApiError if the response is not successful.
+  // options has the method, the query and headers by name,
+  // the body, either a json value, FormData or URLSearchParams, the signal and timeout of CallOptions,
+  // and the security, the alternative lists of the security definitions the operation needs, e.g. [['basic'], ['oauth']]
+  send(endpoint: string, options: any);
+}
+
+// CallOptions are the options of one call of a service method
+export interface CallOptions {
+  // signal aborts the request
+  signal?: AbortSignal;
+  // timeout aborts the request after so many milliseconds
+  timeout?: number;
+  // headers are sent besides the ones of the parameters
+  headers?: { [name: string]: string };
+}
+
+// ApiError is the rejection of a request whose response is not successful,
+// the services type it with the error responses of each operation
+export class ApiError<S extends number = number, B = any> extends Error {
+  readonly status: S;
+  readonly body: B;
+
+  constructor(status: S, body: B, message?: string) {
+    super(message || `request failed with status ${status}`);
+    this.status = status;
+    this.body = body;
+  }
+}
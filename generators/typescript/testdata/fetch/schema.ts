@@ -0,0 +1,20 @@
+
+export interface IError { 
+  code?: number,
+  message?: string,
+}
+
+export interface IPet { 
+  bornAt?: Date,
+  readonly id: bigint,
+  /** @example "rex" */
+  name: string,
+  status?: IStatus,
+  tags?: Record<string, string>,
+}
+
+export enum IStatus { 
+  available = 'available',
+  'it\'s sold' = 'it\'s sold',
+}
+
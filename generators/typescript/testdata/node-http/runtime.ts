@@ -0,0 +1,171 @@
+import * as http from 'http';
+import * as https from 'https';
+import { IRequest, ApiError } from './request';
+
+// defaultBaseUrl is made of the host, schemes and basePath of the spec
+export const defaultBaseUrl = "https://api.example.com/v1";
+
+// Credentials are sent with every request, one per security definition of the spec
+export interface Credentials { 
+  "api_key"?: string;
+  "basic"?: { username: string; password: string };
+}
+
+// RuntimeOptions are the options of every request
+export interface RuntimeOptions {
+  // baseUrl is put before the endpoints, defaultBaseUrl if it is not set
+  baseUrl?: string;
+  // headers are sent with every request
+  headers?: { [name: string]: string };
+  // credentials, or a function returning them, e.g. to refresh a token
+  credentials?: Credentials | (() => Credentials | Promise<Credentials>);
+  // timeout aborts a request after so many milliseconds, unless a call has its own
+  timeout?: number;
+}
+
+// queryString serializes the query, an array, i.e. the value of a multi parameter, is repeated as name=a&name=b
+// and null values are skipped
+export function queryString(query: { [name: string]: any }): string {
+  const parts: string[] = [];
+  Object.keys(query).forEach(name => {
+    const value = query[name];
+    if (value === undefined || value === null) {
+      return;
+    }
+    (Array.isArray(value) ? value : [value]).forEach(item => {
+      const text = item instanceof Date ? item.toISOString() : String(item);
+      parts.push(encodeURIComponent(name) + '=' + encodeURIComponent(text));
+    });
+  });
+  return parts.join('&');
+}
+
+function url(baseUrl: string | undefined, endpoint: string, query: { [name: string]: any }): string {
+  const base = (baseUrl === undefined ? defaultBaseUrl : baseUrl).replace(/\/+$/, '');
+  const search = queryString(query);
+  return base + endpoint + (search ? '?' + search : '');
+}
+
+function base64(text: string): string {
+  if (typeof btoa === 'function') {
+    return btoa(text);
+  }
+  return (globalThis as any).Buffer.from(text).toString('base64');
+}
+
+// authorize puts the credentials of the security the operation needs into the headers or the query,
+// the first alternative whose credentials are all given is used, none if an operation needs no security
+async function authorize(headers: { [name: string]: string }, query: { [name: string]: any }, options: RuntimeOptions, security: string[][] = []) {
+  const credentials: any = typeof options.credentials === 'function' ? await options.credentials() : options.credentials;
+  if (!credentials) {
+    return;
+  }
+  const names = security.find(alternative => alternative.length !== 0 && alternative.every(name => credentials[name] !== undefined)) || [];
+  if (names.indexOf("api_key") >= 0) {
+    headers["X-API-Key"] = credentials["api_key"];
+  }
+  if (names.indexOf("basic") >= 0) {
+    const { username, password } = credentials["basic"];
+    headers['Authorization'] = 'Basic ' + base64(`${username}:${password}`);
+  }
+}
+
+// isRaw tells whether the body is sent as is, otherwise it is sent as json
+function isRaw(body: any): boolean {
+  return (typeof FormData !== 'undefined' && body instanceof FormData)
+    || (typeof URLSearchParams !== 'undefined' && body instanceof URLSearchParams)
+    || (typeof Blob !== 'undefined' && body instanceof Blob);
+}
+
+// requestBody returns the body to send, a json value is stringified and its content type set
+function requestBody(body: any, headers: { [name: string]: string }): any {
+  if (body === undefined || isRaw(body)) {
+    return body;
+  }
+  if (!Object.keys(headers).some(name => name.toLowerCase() === 'content-type')) {
+    headers['Content-Type'] = 'application/json';
+  }
+  return JSON.stringify(body);
+}
+
+// responseBody parses a json response, another one is returned as text,
+// and the one of a 204 or a HEAD request or an empty one as undefined
+function responseBody(method: string, status: number, contentType: string | null | undefined, text: string): any {
+  if (status === 204 || String(method).toUpperCase() === 'HEAD' || !text) {
+    return undefined;
+  }
+  if (contentType && contentType.indexOf('json') >= 0) {
+    return JSON.parse(text);
+  }
+  return text;
+}
+
+// prepare merges the headers, query and credentials of a request
+async function prepare(runtime: RuntimeOptions, options: any) {
+  const headers: { [name: string]: string } = Object.assign({ Accept: 'application/json' }, runtime.headers, options.headers);
+  const query = Object.assign({}, options.query);
+  await authorize(headers, query, runtime, options.security);
+  const timeout: number | undefined = options.timeout !== undefined ? options.timeout : runtime.timeout;
+  return { headers, query, timeout };
+}
+
+// NodeHttpRequest sends the requests of the services with the http and https modules of node,
+// it could not send FormData, a multipart body needs the fetch runtime of node 18 or later
+export class NodeHttpRequest implements IRequest {
+  options: RuntimeOptions;
+  agent?: http.Agent;
+
+  constructor(options: RuntimeOptions = {}, agent?: http.Agent) {
+    this.options = options;
+    this.agent = agent;
+  }
+
+  async send(endpoint: string, options: any): Promise<any> {
+    const { headers, query, timeout } = await prepare(this.options, options);
+    let body = requestBody(options.body, headers);
+    if (typeof FormData !== 'undefined' && body instanceof FormData) {
+      throw new Error('the node-http runtime could not send FormData');
+    }
+    if (body instanceof URLSearchParams) {
+      headers['Content-Type'] = 'application/x-www-form-urlencoded';
+      body = body.toString();
+    } else if (typeof Blob !== 'undefined' && body instanceof Blob) {
+      body = Buffer.from(await body.arrayBuffer());
+    }
+
+    const target = new URL(url(this.options.baseUrl, endpoint, query));
+    const transport = target.protocol === 'https:' ? https : http;
+    return new Promise((resolve, reject) => {
+      const request = transport.request(target, {
+        method: options.method,
+        headers,
+        agent: this.agent,
+        signal: options.signal,
+        timeout,
+      }, response => {
+        const chunks: Buffer[] = [];
+        response.on('data', chunk => chunks.push(chunk));
+        response.on('error', reject);
+        response.on('end', () => {
+          try {
+            const status = response.statusCode || 0;
+            const data = responseBody(options.method, status, response.headers['content-type'], Buffer.concat(chunks).toString('utf8'));
+            if (status < 200 || status >= 300) {
+              reject(new ApiError(status, data, response.statusMessage || undefined));
+              return;
+            }
+            resolve(data);
+          } catch (err) {
+            reject(err);
+          }
+        });
+      });
+      request.on('timeout', () => request.destroy(new Error(`request timed out after ${timeout}ms`)));
+      request.on('error', reject);
+      if (body !== undefined) {
+        request.write(body);
+      }
+      request.end();
+    });
+  }
+}
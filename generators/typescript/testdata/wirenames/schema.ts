@@ -0,0 +1,20 @@
+
+export interface IError { 
+  code?: number,
+  message?: string,
+}
+
+export interface IPet { 
+  born_at?: string,
+  readonly id?: number,
+  /** @example "rex" */
+  name?: string,
+  status?: IStatus,
+  tags?: Record<string, string>,
+}
+
+export enum IStatus { 
+  available = 'available',
+  'it\'s sold' = 'it\'s sold',
+}
+
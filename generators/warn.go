@@ -0,0 +1,15 @@
+package generators
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// Warnings is where generators report the parts of a spec they skip
+var Warnings io.Writer = os.Stderr
+
+// Warn reports a part of the spec which is skipped by a generator
+func Warn(format string, args ...interface{}) {
+	fmt.Fprintf(Warnings, "# Warning: "+format+"\n", args...)
+}
@@ -0,0 +1,210 @@
+package model
+
+import (
+	"strings"
+)
+
+// Codecs describes how values of runtime types, e.g. Date, are converted from and to json,
+// and how the json names of properties are renamed to the keys of typescript.
+//
+// A codec is a typescript literal:
+//
+//	'date-time' | 'date' | 'bigint'     a value of a runtime type
+//	{ ref: 'IPet' }                     a definition, see Definitions
+//	{ array: codec }                    the items of an array
+//	{ map: codec }                      the values of a map
+//	{ object: { wire: codec },          the properties of an object by json name,
+//	  keys: { wire: 'key' } }           and the keys of the ones which are renamed
+//	{ all: [codec] }                    the parts of an allOf
+type Codecs struct {
+	ts    TypeScript
+	model *Model
+	// needs tells which definitions have values to convert
+	needs map[string]bool
+	// renames tells whether a property of the model has another key than its json name
+	renames bool
+}
+
+// Codecs returns the codecs of the model for the formats of ts
+func (ts TypeScript) Codecs(m *Model) *Codecs {
+	c := &Codecs{ts: ts, model: m, needs: make(map[string]bool)}
+	m.walkSchemas(func(s *Schema) {
+		for _, p := range s.Properties {
+			c.renames = c.renames || ts.propertyKey(p) != p.Name
+		}
+	})
+	if !c.Enabled() {
+		return c
+	}
+
+	// definitions could refer to each other, repeat until no definition is added
+	for changed := true; changed; {
+		changed = false
+		for _, s := range m.Schemas {
+			if !c.needs[s.Name] && c.Codec(s) != "" {
+				c.needs[s.Name] = true
+				changed = true
+			}
+		}
+	}
+	return c
+}
+
+// Enabled tells whether a format is mapped to a runtime type, or a property is renamed
+func (c *Codecs) Enabled() bool {
+	if c.renames {
+		return true
+	}
+	for _, t := range c.ts.Formats {
+		if t == TypeDate || t == TypeBigInt {
+			return true
+		}
+	}
+	return false
+}
+
+// Definitions returns the definitions which have values to convert
+func (c *Codecs) Definitions() []*Schema {
+	var defs []*Schema
+	for _, s := range c.model.Schemas {
+		if c.needs[s.Name] {
+			defs = append(defs, s)
+		}
+	}
+	return defs
+}
+
+// Codec returns the codec of the schema, or an empty string if it has no value to convert
+func (c *Codecs) Codec(s *Schema) string {
+	if s == nil {
+		return ""
+	}
+	if s.Ref != "" {
+		if c.needs[s.Ref] {
+			return "{ ref: '" + c.ts.DefinitionName(s.Ref) + "' }"
+		}
+		return ""
+	}
+
+	if len(s.AllOf) != 0 {
+		var parts []string
+		for _, part := range s.AllOf {
+			if codec := c.Codec(part); codec != "" {
+				parts = append(parts, codec)
+			}
+		}
+		if codec := c.object(s); codec != "" {
+			parts = append(parts, codec)
+		}
+		switch len(parts) {
+		case 0:
+			return ""
+		case 1:
+			return parts[0]
+		}
+		return "{ all: [" + strings.Join(parts, ", ") + "] }"
+	}
+
+	switch s.Type {
+	case "array":
+		if codec := c.Codec(s.Items); codec != "" {
+			return "{ array: " + codec + " }"
+		}
+		return ""
+	case "object", "":
+		return c.object(s)
+	}
+
+	if !isScalar(s.Type) {
+		return ""
+	}
+	switch c.ts.Formats[s.Format] {
+	case TypeDate:
+		if s.Format == "date" {
+			return "'date'"
+		}
+		return "'date-time'"
+	case TypeBigInt:
+		return "'bigint'"
+	}
+	return ""
+}
+
+func (c *Codecs) object(s *Schema) string {
+	var props, keys []string
+	for _, p := range s.Properties {
+		if codec := c.Codec(p.Schema); codec != "" {
+			props = append(props, key(p.Name)+": "+codec)
+		}
+		if name := c.ts.propertyKey(p); name != p.Name {
+			keys = append(keys, key(p.Name)+": "+quote(name))
+		}
+	}
+	if len(keys) != 0 {
+		return "{ object: " + objectLiteral(props) + ", keys: " + objectLiteral(keys) + " }"
+	}
+	if len(props) != 0 {
+		return "{ object: " + objectLiteral(props) + " }"
+	}
+	if len(s.Properties) == 0 {
+		if codec := c.Codec(s.AdditionalProperties); codec != "" {
+			return "{ map: " + codec + " }"
+		}
+	}
+	return ""
+}
+
+// Encode returns the typescript expression converting the value of expr to json
+func (c *Codecs) Encode(s *Schema, expr string) string {
+	if codec := c.Codec(s); codec != "" {
+		return "encode(" + codec + ", " + expr + ")"
+	}
+	return expr
+}
+
+// Decode returns the typescript expression converting the json value of expr to runtime types
+func (c *Codecs) Decode(s *Schema, expr string) string {
+	if codec := c.Codec(s); codec != "" {
+		return "decode(" + codec + ", " + expr + ")"
+	}
+	return expr
+}
+
+// Serialize returns the typescript expression of the value of the parameter as it is sent, e.g. ids?.join(','),
+// the encoded items of an array are joined by the separator of its collection format
+func (c *Codecs) Serialize(p *Parameter) string {
+	expr := c.Encode(p.Schema, c.ts.ParameterName(p))
+	sep := p.Separator()
+	if sep == "" {
+		return expr
+	}
+	if p.Required {
+		return expr + ".join(" + quote(sep) + ")"
+	}
+	return expr + "?.join(" + quote(sep) + ")"
+}
+
+// Path returns the path of the operation as the content of a template literal, where a {name} is replaced
+// by the encoded path parameter, e.g. /users/${encodeURIComponent(String(userId))}.
+// A value is serialized first, see Serialize, a {name} without a path parameter is left as is.
+func (c *Codecs) Path(o *Operation) string {
+	params := make(map[string]*Parameter)
+	for _, p := range o.ParametersIn("path") {
+		params[p.Name] = p
+	}
+	return PathWith(o.Path, func(name string) string {
+		p, ok := params[name]
+		if !ok {
+			return "{" + name + "}"
+		}
+		return "${encodeURIComponent(String(" + c.Serialize(p) + "))}"
+	})
+}
+
+// objectLiteral returns the entries as an object literal, e.g. { a: 'date', b: 'bigint' }
+func objectLiteral(entries []string) string {
+	if len(entries) == 0 {
+		return "{}"
+	}
+	return "{ " + strings.Join(entries, ", ") + " }"
+}
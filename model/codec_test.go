@@ -0,0 +1,202 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+const codecsSpec = `{
+  "swagger": "2.0",
+  "info": {"title": "codecs", "version": "1"},
+  "paths": {
+    "/days/{day}/{ids}": {
+      "get": {
+        "operationId": "getDay", "tags": ["days"],
+        "parameters": [
+          {"name": "day", "in": "path", "required": true, "type": "string", "format": "date"},
+          {"name": "ids", "in": "path", "required": true, "type": "array", "items": {"type": "integer", "format": "int64"}},
+          {"name": "at", "in": "query", "type": "string", "format": "date-time"},
+          {"name": "tags", "in": "query", "type": "array", "collectionFormat": "pipes", "items": {"type": "string"}},
+          {"name": "ids_multi", "in": "query", "type": "array", "collectionFormat": "multi", "items": {"type": "string"}},
+          {"name": "times", "in": "query", "required": true, "type": "array", "collectionFormat": "tsv", "items": {"type": "string", "format": "date-time"}},
+          {"name": "name", "in": "query", "type": "string"}
+        ],
+        "responses": {"200": {"description": "ok", "schema": {"$ref": "#/definitions/Day"}}}
+      }
+    },
+    "/users/{user_id}/{missing}": {
+      "get": {
+        "operationId": "getUser", "tags": ["users"],
+        "parameters": [{"name": "user_id", "in": "path", "required": true, "type": "string"}],
+        "responses": {"200": {"description": "ok"}}
+      }
+    }
+  },
+  "definitions": {
+    "Day": {"type": "object", "properties": {
+      "date": {"type": "string", "format": "date"},
+      "events": {"type": "array", "items": {"$ref": "#/definitions/Event"}},
+      "counts": {"type": "object", "additionalProperties": {"type": "integer", "format": "int64"}}
+    }},
+    "Event": {"allOf": [{"$ref": "#/definitions/Base"}, {"type": "object", "properties": {"at": {"type": "string", "format": "date-time"}}}]},
+    "Base": {"type": "object", "properties": {"id": {"type": "string"}}},
+    "User": {"type": "object", "properties": {"user_id": {"type": "string"}, "name": {"type": "string"}}}
+  }
+}`
+
+func TestCodecs(t *testing.T) {
+	m := load(t, codecsSpec)
+	dates := TypeScript{Formats: map[string]string{"date": TypeDate, "date-time": TypeDate, "int64": TypeBigInt}}
+
+	tests := []struct {
+		name        string
+		ts          TypeScript
+		enabled     bool
+		definitions []string
+		codecs      map[string]string
+	}{
+		{
+			name:    "wire names without runtime formats",
+			ts:      TypeScript{WireNames: true, Formats: map[string]string{"int64": "string"}},
+			enabled: false,
+			codecs:  map[string]string{"Day": "", "Event": "", "User": ""},
+		},
+		{
+			name:        "renamed properties",
+			ts:          TypeScript{},
+			enabled:     true,
+			definitions: []string{"User"},
+			codecs: map[string]string{
+				"Day":  "",
+				"User": "{ object: {}, keys: { user_id: 'userId' } }",
+			},
+		},
+		{
+			name:        "dates and bigints",
+			ts:          dates,
+			enabled:     true,
+			definitions: []string{"Day", "Event", "User"},
+			codecs: map[string]string{
+				"Base":  "",
+				"Day":   "{ object: { counts: { map: 'bigint' }, date: 'date', events: { array: { ref: 'IEvent' } } } }",
+				"Event": "{ object: { at: 'date-time' } }",
+				"User":  "{ object: {}, keys: { user_id: 'userId' } }",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := tt.ts
+			ts.Identifiers = ts.Identify(m)
+			c := ts.Codecs(m)
+			if got := c.Enabled(); got != tt.enabled {
+				t.Errorf("Enabled() = %v, want %v", got, tt.enabled)
+			}
+			var definitions []string
+			for _, s := range c.Definitions() {
+				definitions = append(definitions, s.Name)
+			}
+			if !reflect.DeepEqual(definitions, tt.definitions) {
+				t.Errorf("Definitions() = %v, want %v", definitions, tt.definitions)
+			}
+			for name, want := range tt.codecs {
+				if got := c.Codec(m.Schema(name)); got != want {
+					t.Errorf("Codec(%s) = %s, want %s", name, got, want)
+				}
+				if got := c.Codec(&Schema{Ref: name}); (got == "") != (want == "") {
+					t.Errorf("Codec($ref %s) = %s, want a ref if the definition has a codec", name, got)
+				}
+			}
+		})
+	}
+}
+
+func TestCodecsEncode(t *testing.T) {
+	m := load(t, codecsSpec)
+	ts := TypeScript{Formats: map[string]string{"date": TypeDate, "int64": TypeBigInt}}
+	ts.Identifiers = ts.Identify(m)
+	c := ts.Codecs(m)
+
+	day := &Schema{Ref: "Day"}
+	if got, want := c.Decode(day, "data"), "decode({ ref: 'IDay' }, data)"; got != want {
+		t.Errorf("Decode() = %s, want %s", got, want)
+	}
+	if got, want := c.Encode(day, "body"), "encode({ ref: 'IDay' }, body)"; got != want {
+		t.Errorf("Encode() = %s, want %s", got, want)
+	}
+	if got, want := c.Encode(&Schema{Type: "string"}, "name"), "name"; got != want {
+		t.Errorf("Encode() = %s, want %s", got, want)
+	}
+}
+
+func TestCodecsSerialize(t *testing.T) {
+	m := load(t, codecsSpec)
+	ts := TypeScript{Formats: map[string]string{"date-time": TypeDate, "int64": TypeBigInt}}
+	ts.Identifiers = ts.Identify(m)
+	c := ts.Codecs(m)
+	o := operation(t, m, "getDay")
+
+	tests := []struct {
+		param  string
+		format string
+		want   string
+	}{
+		{"day", "", "day"},
+		{"ids", "csv", "encode({ array: 'bigint' }, ids).join(',')"},
+		{"at", "", "encode('date-time', at)"},
+		{"tags", "pipes", "tags?.join('|')"},
+		{"ids_multi", "multi", "idsMulti"},
+		{"times", "tsv", `encode({ array: 'date-time' }, times).join('\t')`},
+		{"name", "", "name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.param, func(t *testing.T) {
+			p := parameter(t, o, tt.param)
+			if p.CollectionFormat != tt.format {
+				t.Errorf("CollectionFormat = %q, want %q", p.CollectionFormat, tt.format)
+			}
+			if got := c.Serialize(p); got != tt.want {
+				t.Errorf("Serialize() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCodecsPath(t *testing.T) {
+	m := load(t, codecsSpec)
+	tests := []struct {
+		name string
+		ts   TypeScript
+		op   string
+		want string
+	}{
+		{
+			name: "encoded parameters",
+			op:   "getUser",
+			want: "/users/${encodeURIComponent(String(userId))}/{missing}",
+		},
+		{
+			name: "strings and numbers",
+			op:   "getDay",
+			want: "/days/${encodeURIComponent(String(day))}/${encodeURIComponent(String(ids.join(',')))}",
+		},
+		{
+			name: "dates and bigints",
+			ts:   TypeScript{Formats: map[string]string{"date": TypeDate, "int64": TypeBigInt}},
+			op:   "getDay",
+			want: "/days/${encodeURIComponent(String(encode('date', day)))}/${encodeURIComponent(String(encode({ array: 'bigint' }, ids).join(',')))}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := tt.ts
+			ts.Identifiers = ts.Identify(m)
+			if got := ts.Codecs(m).Path(operation(t, m, tt.op)); got != tt.want {
+				t.Errorf("Path() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,319 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"unicode"
+
+	"github.com/xreception/go-swagen/utils"
+)
+
+// Identifiers are the typescript identifiers of the names in a model, they are unique in their scope:
+// the definitions, the operations, the parameters of an operation and the properties of an object.
+// Names which make the same identifier get it in the order of the model, then with a number, e.g. userId2.
+type Identifiers struct {
+	services    map[string]string
+	files       map[string]string
+	instances   map[string]string
+	definitions map[string]string
+	operations  map[*Operation]string
+	parameters  map[*Parameter]string
+	properties  map[*Property]string
+
+	// the names of the react-redux generator
+	groups    map[string]string
+	constants map[*Operation]string
+	entities  map[string]string
+}
+
+// members are the names taken in a service class before its operations,
+// api would make an error type clash with ApiError
+var members = []string{"constructor", "request", "api"}
+
+// locals are the variables of the built-in operation templates, they could not be parameters
+var locals = []string{"options", "form", "query", "QueryString", "call"}
+
+// modules are the files written besides the services, a service could not be written to them
+var modules = []string{"index", "schema", "request", "convert", "runtime"}
+
+// exports are the names exported by index.ts besides the definitions and services,
+// i.e. the ones of request.ts, convert.ts, runtime.ts and index.ts
+var exports = []string{
+	"IRequest", "CallOptions", "ApiError",
+	"Codec", "codecs", "decode", "encode",
+	"defaultBaseUrl", "Credentials", "RuntimeOptions", "queryString", "FetchRequest", "AxiosRequest", "NodeHttpRequest",
+	"Services", "createServices",
+}
+
+// reduxModules are the names of the react-redux modules which are not definitions,
+// the imports and functions of api.ts and action.ts, the action groups of the tags are exported besides them
+var reduxModules = []string{"qs", "decode", "encode", "config", "myFetch", "fetchAPI", "schema", "api", "cs", "sc", "createActionTypes"}
+
+// actionTypes are the suffixes of the action types of an operation in constant.ts
+var actionTypes = []string{"", "_REQUEST", "_SUCCESS", "_FAIL"}
+
+var fileName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Identify returns the identifiers of the names in the model
+func (ts TypeScript) Identify(m *Model) *Identifiers {
+	ids := &Identifiers{
+		services:    make(map[string]string),
+		files:       make(map[string]string),
+		instances:   make(map[string]string),
+		definitions: make(map[string]string),
+		operations:  make(map[*Operation]string),
+		parameters:  make(map[*Parameter]string),
+		properties:  make(map[*Property]string),
+		groups:      make(map[string]string),
+		constants:   make(map[*Operation]string),
+		entities:    make(map[string]string),
+	}
+
+	// definitions and services are exported by index.ts together
+	exported := newScope(exports)
+	for _, s := range m.Schemas {
+		ids.definitions[s.Name] = exported.add(definitionName(s.Name))
+	}
+
+	instances, files := scope{}, newScope(modules)
+	for _, t := range m.Tags {
+		ids.services[t.Name] = exported.add(serviceName(t.Name))
+		ids.instances[t.Name] = instances.add(instanceName(ids.services[t.Name]))
+		ids.files[t.Name] = files.addFile(moduleName(t.Name))
+	}
+
+	operations := newScope(members)
+	for _, o := range m.Operations {
+		ids.operations[o] = operations.add(operationName(o))
+		params := newScope(locals)
+		for _, p := range o.Parameters {
+			ids.parameters[p] = params.add(parameterName(p))
+		}
+	}
+
+	// an action group is exported by api.ts and action.ts, the latter with its ActionTypes
+	groups := newScope(reduxModules)
+	for _, name := range ids.definitions {
+		groups[name] = true
+	}
+	for _, t := range m.Tags {
+		ids.groups[t.Name] = groups.addWith(groupName(t.Name), "", "ActionTypes")
+	}
+	constants := newScope([]string{"CALL_API"})
+	for _, o := range m.Operations {
+		ids.constants[o] = constants.addWith(constantName(o), actionTypes...)
+	}
+	entities := newScope([]string{"schema"})
+	for _, s := range m.Schemas {
+		ids.entities[s.Name] = entities.add(entityName(s.Name))
+	}
+
+	m.walkSchemas(func(s *Schema) {
+		props := scope{}
+		for _, p := range s.Properties {
+			ids.properties[p] = props.add(ts.propertyName(p))
+		}
+	})
+	return ids
+}
+
+func newScope(names []string) scope {
+	s := scope{}
+	for _, name := range names {
+		s[name] = true
+	}
+	return s
+}
+
+func serviceName(tag string) string {
+	return TypeScriptNaming.Identifier(tag, func(s string) string { return s })
+}
+
+// moduleName is the tag if it is a safe file name, otherwise its service name
+func moduleName(tag string) string {
+	if fileName.MatchString(tag) {
+		return tag
+	}
+	return serviceName(tag)
+}
+
+// instanceName is the service name starting in lower case, e.g. accountService for AccountService
+func instanceName(service string) string {
+	name := []rune(service)
+	name[0] = unicode.ToLower(name[0])
+	return TypeScriptNaming.Identifier(string(name), func(s string) string { return s })
+}
+
+func definitionName(name string) string {
+	return TypeScriptNaming.Identifier(name, utils.InterfaceCase)
+}
+
+func operationName(o *Operation) string {
+	return TypeScriptNaming.Identifier(o.ID, utils.CamelCase)
+}
+
+func parameterName(p *Parameter) string {
+	return TypeScriptNaming.Identifier(p.Name, utils.CamelCase)
+}
+
+func groupName(tag string) string {
+	return TypeScriptNaming.Identifier(tag, utils.CamelCase)
+}
+
+// constantName is the action type of the operation, prefixed with its first tag
+func constantName(o *Operation) string {
+	prefix := ""
+	if len(o.Tags) != 0 {
+		prefix = o.Tags[0]
+	}
+	return TypeScriptNaming.Identifier(prefix+o.ID, utils.UpperSnakeCase)
+}
+
+func entityName(name string) string {
+	return TypeScriptNaming.Identifier(name, utils.CamelCase)
+}
+
+// propertyName is not escaped, a property is quoted if it is not an identifier
+func (ts TypeScript) propertyName(p *Property) string {
+	if ts.WireNames {
+		return p.Name
+	}
+	if name := utils.CamelCase(p.Name); name != "" {
+		return name
+	}
+	return p.Name
+}
+
+// ServiceName returns the name of the service class of the tag, the tag without illegal characters
+func (ts TypeScript) ServiceName(tag string) string {
+	if ts.Identifiers != nil {
+		if id, ok := ts.Identifiers.services[tag]; ok {
+			return id
+		}
+	}
+	return serviceName(tag)
+}
+
+// ModuleName returns the name of the file of the service of the tag without .ts, e.g. account for account.
+// A tag which is not a safe file name gets its service name, one taken by another file a number.
+func (ts TypeScript) ModuleName(tag string) string {
+	if ts.Identifiers != nil {
+		if id, ok := ts.Identifiers.files[tag]; ok {
+			return id
+		}
+	}
+	return moduleName(tag)
+}
+
+// InstanceName returns the name of the property holding the service of the tag, e.g. accountService
+func (ts TypeScript) InstanceName(tag string) string {
+	if ts.Identifiers != nil {
+		if id, ok := ts.Identifiers.instances[tag]; ok {
+			return id
+		}
+	}
+	return instanceName(serviceName(tag))
+}
+
+// DefinitionName returns the name of the interface, enum or type of the definition, e.g. IPet for Pet
+func (ts TypeScript) DefinitionName(name string) string {
+	if ts.Identifiers != nil {
+		if id, ok := ts.Identifiers.definitions[name]; ok {
+			return id
+		}
+	}
+	return definitionName(name)
+}
+
+// OperationName returns the name of the method of the operation
+func (ts TypeScript) OperationName(o *Operation) string {
+	if ts.Identifiers != nil {
+		if id, ok := ts.Identifiers.operations[o]; ok {
+			return id
+		}
+	}
+	return operationName(o)
+}
+
+// GroupName returns the name of the action group of the tag in react-redux, e.g. pet for Pet
+func (ts TypeScript) GroupName(tag string) string {
+	if ts.Identifiers != nil {
+		if id, ok := ts.Identifiers.groups[tag]; ok {
+			return id
+		}
+	}
+	return groupName(tag)
+}
+
+// ConstantName returns the action type of the operation in react-redux, e.g. PET_GET_PET
+func (ts TypeScript) ConstantName(o *Operation) string {
+	if ts.Identifiers != nil {
+		if id, ok := ts.Identifiers.constants[o]; ok {
+			return id
+		}
+	}
+	return constantName(o)
+}
+
+// EntityName returns the name of the normalizr schema of the definition in react-redux, e.g. pet for Pet
+func (ts TypeScript) EntityName(name string) string {
+	if ts.Identifiers != nil {
+		if id, ok := ts.Identifiers.entities[name]; ok {
+			return id
+		}
+	}
+	return entityName(name)
+}
+
+// ErrorName returns the name of the union of the errors of the operation, e.g. GetUserError
+func (ts TypeScript) ErrorName(o *Operation) string {
+	name := []rune(ts.OperationName(o))
+	name[0] = unicode.ToUpper(name[0])
+	return string(name) + "Error"
+}
+
+// ParameterName returns the name of the parameter in typescript
+func (ts TypeScript) ParameterName(p *Parameter) string {
+	if ts.Identifiers != nil {
+		if id, ok := ts.Identifiers.parameters[p]; ok {
+			return id
+		}
+	}
+	return parameterName(p)
+}
+
+// PropertyName returns the key of the property in typescript, quoted if it is not an identifier
+func (ts TypeScript) PropertyName(p *Property) string {
+	return key(ts.propertyKey(p))
+}
+
+// propertyKey returns the unquoted key of the property in typescript
+func (ts TypeScript) propertyKey(p *Property) string {
+	if ts.Identifiers != nil {
+		if id, ok := ts.Identifiers.properties[p]; ok {
+			return id
+		}
+	}
+	return ts.propertyName(p)
+}
+
+// key returns the name as the key of an object, quoted if it is not an identifier
+func key(name string) string {
+	if !IsIdentifier(name) {
+		return quote(name)
+	}
+	return name
+}
+
+// EnumMember returns the name of the member of an enum for the value,
+// quoted if it is not an identifier, with a leading _ if it starts with a digit
+func (ts TypeScript) EnumMember(value interface{}) string {
+	name := fmt.Sprint(value)
+	if name != "" && unicode.IsDigit([]rune(name)[0]) {
+		name = "_" + name
+	}
+	if !IsIdentifier(name) {
+		return quote(name)
+	}
+	return name
+}
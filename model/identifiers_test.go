@@ -0,0 +1,157 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/spec"
+)
+
+// load builds the model of a swagger spec in json
+func load(t *testing.T, doc string) *Model {
+	t.Helper()
+	swagger := &spec.Swagger{}
+	if err := json.Unmarshal([]byte(doc), swagger); err != nil {
+		t.Fatal(err)
+	}
+	return New(swagger)
+}
+
+// operation returns the operation of the model with given id
+func operation(t *testing.T, m *Model, id string) *Operation {
+	t.Helper()
+	for _, o := range m.Operations {
+		if o.ID == id {
+			return o
+		}
+	}
+	t.Fatalf("no operation %s", id)
+	return nil
+}
+
+// parameter returns the parameter of the operation with given name
+func parameter(t *testing.T, o *Operation, name string) *Parameter {
+	t.Helper()
+	for _, p := range o.Parameters {
+		if p.Name == name {
+			return p
+		}
+	}
+	t.Fatalf("no parameter %s in %s", name, o.ID)
+	return nil
+}
+
+// property returns the property of the definition with given name
+func property(t *testing.T, m *Model, definition, name string) *Property {
+	t.Helper()
+	for _, p := range m.Schema(definition).Properties {
+		if p.Name == name {
+			return p
+		}
+	}
+	t.Fatalf("no property %s in %s", name, definition)
+	return nil
+}
+
+const namesSpec = `{
+  "swagger": "2.0",
+  "info": {"title": "names", "version": "1"},
+  "paths": {
+    "/users/{user id}": {
+      "get": {
+        "operationId": "get-user", "tags": ["pet store"],
+        "parameters": [
+          {"name": "user id", "in": "path", "required": true, "type": "string"},
+          {"name": "userId", "in": "query", "type": "string"},
+          {"name": "options", "in": "query", "type": "string"},
+          {"name": "class", "in": "query", "type": "string"}
+        ],
+        "responses": {"200": {"description": "ok"}}
+      },
+      "put": {"operationId": "get_user", "tags": ["index"], "responses": {"200": {"description": "ok"}}},
+      "post": {"operationId": "constructor", "tags": ["Services"], "responses": {"200": {"description": "ok"}}},
+      "delete": {"operationId": "delete", "tags": ["Index"], "responses": {"200": {"description": "ok"}}}
+    }
+  },
+  "definitions": {
+    "Request": {"type": "string"},
+    "Pet": {"type": "object", "properties": {"user_id": {"type": "string"}, "userId": {"type": "string"}, "2fa": {"type": "boolean"}}},
+    "pet": {"type": "string"}
+  }
+}`
+
+func TestIdentify(t *testing.T) {
+	m := load(t, namesSpec)
+	ts := TypeScript{}
+	ts.Identifiers = ts.Identify(m)
+	get := operation(t, m, "get-user")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		// definitions are numbered in the order of their names, and do not clash with the exports of index.ts
+		{"definition", ts.DefinitionName("Pet"), "IPet"},
+		{"clashing definition", ts.DefinitionName("pet"), "IPet2"},
+		{"definition named like an export", ts.DefinitionName("Request"), "IRequest2"},
+		{"unknown definition", ts.DefinitionName("order_item"), "IOrderItem"},
+
+		// services are numbered in the order of the tags sorted by name, i.e. Index before index,
+		// files are safe names which are not taken by the generated modules
+		{"service", ts.ServiceName("pet store"), "petstore"},
+		{"service named like an export", ts.ServiceName("Services"), "Services2"},
+		{"instance", ts.InstanceName("Services"), "services2"},
+		{"module of an unsafe tag", ts.ModuleName("pet store"), "petstore"},
+		{"module of a generated file", ts.ModuleName("Index"), "Index2"},
+		{"module differing in case only", ts.ModuleName("index"), "index3"},
+		{"module of a safe tag", ts.ModuleName("Services"), "Services"},
+
+		// operations are unique in the service class and do not clash with its members
+		{"operation", ts.OperationName(get), "getUser"},
+		{"clashing operation", ts.OperationName(operation(t, m, "get_user")), "getUser2"},
+		{"member", ts.OperationName(operation(t, m, "constructor")), "constructor2"},
+		{"reserved word", ts.OperationName(operation(t, m, "delete")), "delete_"},
+		{"error union", ts.ErrorName(get), "GetUserError"},
+
+		// parameters are unique in the operation and do not clash with the locals of the templates
+		{"parameter", ts.ParameterName(parameter(t, get, "user id")), "userId"},
+		{"clashing parameter", ts.ParameterName(parameter(t, get, "userId")), "userId2"},
+		{"local", ts.ParameterName(parameter(t, get, "options")), "options2"},
+		{"reserved parameter", ts.ParameterName(parameter(t, get, "class")), "class_"},
+
+		// properties are unique in the object and quoted if they are no identifiers
+		{"property", ts.PropertyName(property(t, m, "Pet", "userId")), "userId"},
+		{"clashing property", ts.PropertyName(property(t, m, "Pet", "user_id")), "userId2"},
+		{"quoted property", ts.PropertyName(property(t, m, "Pet", "2fa")), "'2fa'"},
+
+		// the names of react-redux are unique in the modules they are exported by
+		{"action group", ts.GroupName("pet store"), "petStore"},
+		{"action type", ts.ConstantName(operation(t, m, "delete")), "INDEXDELETE"},
+		{"normalizr schema", ts.EntityName("Pet"), "pet"},
+		{"clashing normalizr schema", ts.EntityName("pet"), "pet2"},
+
+		{"enum member", ts.EnumMember("it's"), `'it\'s'`},
+		{"numeric enum member", ts.EnumMember(float64(1)), "_1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %s, want %s", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIdentifyWireNames(t *testing.T) {
+	m := load(t, namesSpec)
+	ts := TypeScript{WireNames: true}
+	ts.Identifiers = ts.Identify(m)
+
+	for name, want := range map[string]string{"user_id": "user_id", "userId": "userId", "2fa": "'2fa'"} {
+		if got := ts.PropertyName(property(t, m, "Pet", name)); got != want {
+			t.Errorf("PropertyName(%s) = %s, want %s", name, got, want)
+		}
+	}
+}
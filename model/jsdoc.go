@@ -0,0 +1,107 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// JSDoc returns the documentation of a schema, property, parameter, operation or security scheme as a JSDoc block followed by
+// a new line and indent, so that it could be put before a declaration. It is empty if there is nothing to document.
+//
+// The description, or the title of a schema, or the summary and description of an operation make the text,
+// followed by @deprecated, @example and @default tags.
+func (ts TypeScript) JSDoc(v interface{}, indent string) string {
+	switch doc := v.(type) {
+	case *Schema:
+		return jsdoc([]string{firstOf(doc.Description, doc.Title)}, tagsOf(doc.Deprecated, doc), indent)
+	case *Property:
+		if doc.Schema == nil {
+			return ""
+		}
+		return ts.JSDoc(doc.Schema, indent)
+	case *Parameter:
+		return jsdoc([]string{doc.Description}, tagsOf(doc.Deprecated || doc.Schema.Deprecated, doc.Schema), indent)
+	case *Operation:
+		return jsdoc([]string{doc.Summary, doc.Description}, tagsOf(doc.Deprecated, nil), indent)
+	case *SecurityScheme:
+		return jsdoc([]string{doc.Description}, nil, indent)
+	}
+	return ""
+}
+
+// tagsOf returns the @deprecated tag, and the @example and @default tags of the schema
+func tagsOf(deprecated bool, s *Schema) []string {
+	var tags []string
+	if deprecated {
+		tags = append(tags, "@deprecated")
+	}
+	if s != nil && s.Example != nil {
+		tags = append(tags, "@example "+jsValue(s.Example))
+	}
+	if s != nil && s.Default != nil {
+		tags = append(tags, "@default "+jsValue(s.Default))
+	}
+	return tags
+}
+
+// jsdoc renders the paragraphs and the tags as a JSDoc block, on one line if it is a single line of text
+func jsdoc(paragraphs []string, tags []string, indent string) string {
+	var lines []string
+	for _, p := range paragraphs {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if len(lines) != 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, strings.Split(p, "\n")...)
+	}
+	if len(lines) != 0 && len(tags) != 0 {
+		lines = append(lines, "")
+	}
+	lines = append(lines, tags...)
+
+	switch len(lines) {
+	case 0:
+		return ""
+	case 1:
+		return "/** " + escapeComment(lines[0]) + " */\n" + indent
+	}
+	var b strings.Builder
+	b.WriteString("/**\n")
+	for _, line := range lines {
+		line = strings.TrimRight(escapeComment(line), " \t\r")
+		if line == "" {
+			b.WriteString(indent + " *\n")
+		} else {
+			b.WriteString(indent + " * " + line + "\n")
+		}
+	}
+	b.WriteString(indent + " */\n" + indent)
+	return b.String()
+}
+
+// escapeComment keeps the text from closing the comment
+func escapeComment(s string) string {
+	return strings.Replace(s, "*/", "*\\/", -1)
+}
+
+// jsValue returns the value as json, which is a javascript literal
+func jsValue(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+func firstOf(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,575 @@
+// Package model normalizes a swagger spec into the data passed to the templates.
+//
+// The model is stable: the fields documented here are what templates of the template generator rely on,
+// they are only added to, never renamed or removed.
+package model
+
+import (
+	"sort"
+
+	"github.com/go-openapi/spec"
+	"github.com/xreception/go-swagen/utils"
+)
+
+// DefaultTag groups the operations which have no tag
+const DefaultTag = "default"
+
+// Methods are the http methods of the operations in the order they appear in a path
+var Methods = []string{"GET", "PUT", "POST", "DELETE", "OPTIONS", "HEAD", "PATCH"}
+
+// Model is the root of the data model.
+type Model struct {
+	Title       string
+	Description string
+	Version     string
+	Host        string
+	BasePath    string
+	Schemes     []string
+	// Schemas are the definitions sorted by name
+	Schemas []*Schema
+	// Operations are sorted by path, then by method in the order of Methods
+	Operations []*Operation
+	// Tags are sorted by name, operations without tag are in the DefaultTag
+	Tags []*Tag
+	// Security are the security definitions sorted by name
+	Security []*SecurityScheme
+}
+
+// SecurityScheme is a security definition
+type SecurityScheme struct {
+	Name string
+	// Type is basic, apiKey or oauth2
+	Type        string
+	Description string
+	// In is header or query for an apiKey, Key is the name of the header or query parameter
+	In  string
+	Key string
+}
+
+// Tag is a group of operations
+type Tag struct {
+	Name        string
+	Description string
+	Operations  []*Operation
+}
+
+// Operation is a method of a path
+type Operation struct {
+	// ID is the operationId, the method and path if missing, e.g. GET /users/{id}
+	ID          string
+	Method      string
+	Path        string
+	Summary     string
+	Description string
+	Deprecated  bool
+	Tags        []string
+	Consumes    []string
+	Produces    []string
+	// Parameters include the ones of the path
+	Parameters []*Parameter
+	// Responses are sorted by status code, the default response is the last one
+	Responses []*Response
+	// Security are the alternative requirements of the operation, or of the spec if the operation has none,
+	// a requirement is the sorted names of the security schemes it needs together, an empty one needs none
+	Security   [][]string
+	Extensions map[string]interface{}
+}
+
+// Parameter is a parameter of an operation
+type Parameter struct {
+	Name        string
+	In          string
+	Description string
+	Required    bool
+	// Deprecated is set by x-deprecated
+	Deprecated bool
+	// CollectionFormat is how the items of an array are sent, i.e. csv, ssv, tsv, pipes or multi,
+	// it defaults to csv for an array and is empty for another parameter
+	CollectionFormat string
+	// Schema is the type of the parameter, it is never nil
+	Schema *Schema
+}
+
+// separators are the separators of the collection formats joining the items of an array
+var separators = map[string]string{"csv": ",", "ssv": " ", "tsv": "\t", "pipes": "|"}
+
+// Separator returns the separator joining the items of an array parameter, e.g. , for csv,
+// or an empty string if the items are repeated, i.e. multi, or the parameter is no array
+func (p *Parameter) Separator() string {
+	return separators[p.CollectionFormat]
+}
+
+// Response is a possible response of an operation
+type Response struct {
+	// Code is the http status code, 0 for the default response
+	Code        int
+	Description string
+	// Schema is nil if the response has no body
+	Schema *Schema
+}
+
+// Schema is a definition, or the type of a property, parameter or response.
+type Schema struct {
+	// Name is the name of a definition, empty for other schemas
+	Name string
+	// Ref is the name of the referenced definition, the other fields but Nullable are empty if it is set
+	Ref         string
+	Type        string
+	Format      string
+	Title       string
+	Description string
+	Items       *Schema
+	// Properties are sorted by name
+	Properties []*Property
+	// AdditionalProperties is the type of the values of a map, it has no type if any value is allowed
+	AdditionalProperties *Schema
+	AllOf                []*Schema
+	Enum                 []interface{}
+	Default              interface{}
+	Example              interface{}
+	ReadOnly             bool
+	// Nullable is set by x-nullable or a null type
+	Nullable bool
+	// Deprecated is set by x-deprecated
+	Deprecated bool
+	Extensions map[string]interface{}
+}
+
+// Property is a property of an object schema
+type Property struct {
+	Name     string
+	Required bool
+	Schema   *Schema
+}
+
+// New builds the model of the swagger spec
+func New(swagger *spec.Swagger) *Model {
+	m := &Model{}
+	if swagger.Info != nil {
+		m.Title = swagger.Info.Title
+		m.Description = swagger.Info.Description
+		m.Version = swagger.Info.Version
+	}
+	m.Host = swagger.Host
+	m.BasePath = swagger.BasePath
+	m.Schemes = swagger.Schemes
+
+	for _, name := range utils.SortedStringKeys(swagger.Definitions) {
+		schema := swagger.Definitions[name]
+		s := newSchema(&schema)
+		s.Name = name
+		m.Schemas = append(m.Schemas, s)
+	}
+
+	for _, name := range utils.SortedStringKeys(swagger.SecurityDefinitions) {
+		scheme := swagger.SecurityDefinitions[name]
+		if scheme == nil {
+			continue
+		}
+		m.Security = append(m.Security, &SecurityScheme{
+			Name:        name,
+			Type:        scheme.Type,
+			Description: scheme.Description,
+			In:          scheme.In,
+			Key:         scheme.Name,
+		})
+	}
+
+	if swagger.Paths != nil {
+		for _, path := range utils.SortedStringKeys(swagger.Paths.Paths) {
+			item := swagger.Paths.Paths[path]
+			for _, method := range Methods {
+				if op := operationOf(&item, method); op != nil {
+					m.Operations = append(m.Operations, newOperation(swagger, &item, method, path, op))
+				}
+			}
+		}
+	}
+
+	tags := make(map[string]*Tag)
+	for _, t := range swagger.Tags {
+		tags[t.Name] = &Tag{Name: t.Name, Description: t.Description}
+	}
+	for _, op := range m.Operations {
+		names := op.Tags
+		if len(names) == 0 {
+			names = []string{DefaultTag}
+		}
+		for _, name := range names {
+			if _, ok := tags[name]; !ok {
+				tags[name] = &Tag{Name: name}
+			}
+			tags[name].Operations = append(tags[name].Operations, op)
+		}
+	}
+	for _, name := range utils.SortedStringKeys(tags) {
+		if len(tags[name].Operations) != 0 {
+			m.Tags = append(m.Tags, tags[name])
+		}
+	}
+
+	return m
+}
+
+// Schema returns the definition with the given name, or nil
+func (m *Model) Schema(name string) *Schema {
+	for _, s := range m.Schemas {
+		if s.Name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// Tag returns the tag with the given name, or nil
+func (m *Model) Tag(name string) *Tag {
+	for _, t := range m.Tags {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// Resolve returns the definition referenced by the schema, the schema itself if it is not a reference.
+// It returns nil if the definition does not exist.
+func (m *Model) Resolve(s *Schema) *Schema {
+	if s == nil || s.Ref == "" {
+		return s
+	}
+	return m.Schema(s.Ref)
+}
+
+// Refs returns the names of the definitions referenced by the schema, its items, properties and compositions,
+// without following the references
+func (s *Schema) Refs() []string {
+	refs := make(map[string]bool)
+	s.collectRefs(refs)
+	return utils.SortedStringKeys(refs)
+}
+
+func (s *Schema) collectRefs(refs map[string]bool) {
+	if s == nil {
+		return
+	}
+	if s.Ref != "" {
+		refs[s.Ref] = true
+		return
+	}
+	s.Items.collectRefs(refs)
+	s.AdditionalProperties.collectRefs(refs)
+	for _, part := range s.AllOf {
+		part.collectRefs(refs)
+	}
+	for _, p := range s.Properties {
+		p.Schema.collectRefs(refs)
+	}
+}
+
+// walkSchemas calls fn with the definitions, the schemas of the parameters and responses,
+// and every schema in them, in the order of the model
+func (m *Model) walkSchemas(fn func(*Schema)) {
+	for _, s := range m.Schemas {
+		s.walk(fn)
+	}
+	for _, o := range m.Operations {
+		for _, p := range o.Parameters {
+			p.Schema.walk(fn)
+		}
+		for _, r := range o.Responses {
+			r.Schema.walk(fn)
+		}
+	}
+}
+
+func (s *Schema) walk(fn func(*Schema)) {
+	if s == nil {
+		return
+	}
+	fn(s)
+	for _, p := range s.Properties {
+		p.Schema.walk(fn)
+	}
+	s.Items.walk(fn)
+	s.AdditionalProperties.walk(fn)
+	for _, part := range s.AllOf {
+		part.walk(fn)
+	}
+}
+
+// ParametersIn returns the parameters of the location, i.e. path, query, header, body or formData
+func (o *Operation) ParametersIn(in string) []*Parameter {
+	var params []*Parameter
+	for _, p := range o.Parameters {
+		if p.In == in {
+			params = append(params, p)
+		}
+	}
+	return params
+}
+
+// Body returns the body parameter, or nil
+func (o *Operation) Body() *Parameter {
+	for _, p := range o.Parameters {
+		if p.In == "body" {
+			return p
+		}
+	}
+	return nil
+}
+
+// Multipart tells whether the formData parameters are sent as multipart/form-data,
+// i.e. one of them is a file or the operation does not consume application/x-www-form-urlencoded
+func (o *Operation) Multipart() bool {
+	for _, p := range o.ParametersIn("formData") {
+		if p.Schema.Type == "file" {
+			return true
+		}
+	}
+	return !utils.Contains(o.Consumes, "application/x-www-form-urlencoded")
+}
+
+// Success returns the 200 response, or the first 2xx one, or nil
+func (o *Operation) Success() *Response {
+	var success *Response
+	for _, r := range o.Responses {
+		if r.Code == 200 {
+			return r
+		}
+		if success == nil && r.IsSuccess() {
+			success = r
+		}
+	}
+	return success
+}
+
+// Errors returns the 4xx and 5xx responses, and the default one
+func (o *Operation) Errors() []*Response {
+	var errors []*Response
+	for _, r := range o.Responses {
+		if r.IsError() {
+			errors = append(errors, r)
+		}
+	}
+	return errors
+}
+
+// IsSuccess tells whether the status code is 2xx
+func (r *Response) IsSuccess() bool {
+	return r.Code >= 200 && r.Code < 300
+}
+
+// IsError tells whether the status code is 4xx or 5xx, or the response is the default one
+func (r *Response) IsError() bool {
+	return r.Code >= 400 || r.Code == 0
+}
+
+func operationOf(item *spec.PathItem, method string) *spec.Operation {
+	switch method {
+	case "GET":
+		return item.Get
+	case "PUT":
+		return item.Put
+	case "POST":
+		return item.Post
+	case "DELETE":
+		return item.Delete
+	case "OPTIONS":
+		return item.Options
+	case "HEAD":
+		return item.Head
+	case "PATCH":
+		return item.Patch
+	}
+	return nil
+}
+
+func newOperation(swagger *spec.Swagger, item *spec.PathItem, method string, path string, op *spec.Operation) *Operation {
+	o := &Operation{
+		ID:          op.ID,
+		Method:      method,
+		Path:        path,
+		Summary:     op.Summary,
+		Description: op.Description,
+		Deprecated:  op.Deprecated,
+		Tags:        op.Tags,
+		Consumes:    op.Consumes,
+		Produces:    op.Produces,
+		Extensions:  op.Extensions,
+	}
+	if o.ID == "" {
+		o.ID = method + " " + path
+	}
+
+	// security: [] of an operation needs no security, a missing one the security of the spec
+	requirements := op.Security
+	if requirements == nil {
+		requirements = swagger.Security
+	}
+	for _, r := range requirements {
+		names := append([]string{}, utils.SortedStringKeys(r)...)
+		o.Security = append(o.Security, names)
+	}
+
+	// parameters of the operation override the ones of the path with the same name and location
+	var params []spec.Parameter
+	for _, p := range op.Parameters {
+		params = append(params, resolveParameter(swagger, p))
+	}
+	for _, p := range item.Parameters {
+		p = resolveParameter(swagger, p)
+		overridden := false
+		for _, q := range params {
+			overridden = overridden || (q.Name == p.Name && q.In == p.In)
+		}
+		if !overridden {
+			params = append(params, p)
+		}
+	}
+	for i := range params {
+		o.Parameters = append(o.Parameters, newParameter(&params[i]))
+	}
+
+	if op.Responses != nil {
+		codes := make([]int, 0, len(op.Responses.StatusCodeResponses))
+		for code := range op.Responses.StatusCodeResponses {
+			codes = append(codes, code)
+		}
+		sort.Ints(codes)
+		for _, code := range codes {
+			resp := resolveResponse(swagger, op.Responses.StatusCodeResponses[code])
+			o.Responses = append(o.Responses, newResponse(code, &resp))
+		}
+		if op.Responses.Default != nil {
+			resp := resolveResponse(swagger, *op.Responses.Default)
+			o.Responses = append(o.Responses, newResponse(0, &resp))
+		}
+	}
+
+	return o
+}
+
+func newParameter(p *spec.Parameter) *Parameter {
+	param := &Parameter{
+		Name:        p.Name,
+		In:          p.In,
+		Description: p.Description,
+		Required:    p.Required,
+		Deprecated:  isDeprecated(p.Extensions),
+	}
+	if p.Schema != nil {
+		param.Schema = newSchema(p.Schema)
+	} else {
+		param.Schema = newSimpleSchema(&p.SimpleSchema)
+		param.Schema.Enum = p.Enum
+		if param.Schema.Type == "array" {
+			param.CollectionFormat = p.CollectionFormat
+			if param.CollectionFormat == "" {
+				param.CollectionFormat = "csv"
+			}
+		}
+	}
+	return param
+}
+
+func newResponse(code int, r *spec.Response) *Response {
+	resp := &Response{Code: code, Description: r.Description}
+	if r.Schema != nil {
+		resp.Schema = newSchema(r.Schema)
+	}
+	return resp
+}
+
+func newSimpleSchema(s *spec.SimpleSchema) *Schema {
+	schema := &Schema{
+		Type:    s.Type,
+		Format:  s.Format,
+		Default: s.Default,
+		Example: s.Example,
+	}
+	if s.Items != nil {
+		schema.Items = newSimpleSchema(&s.Items.SimpleSchema)
+		schema.Items.Enum = s.Items.Enum
+	}
+	return schema
+}
+
+func newSchema(s *spec.Schema) *Schema {
+	if ref := s.Ref.String(); ref != "" {
+		return &Schema{Ref: RefName(ref), Nullable: isNullable(s), Deprecated: isDeprecated(s.Extensions)}
+	}
+
+	schema := &Schema{
+		Format:      s.Format,
+		Title:       s.Title,
+		Description: s.Description,
+		Enum:        s.Enum,
+		Default:     s.Default,
+		Example:     s.Example,
+		ReadOnly:    s.ReadOnly,
+		Nullable:    isNullable(s),
+		Deprecated:  isDeprecated(s.Extensions),
+		Extensions:  s.Extensions,
+	}
+	for _, t := range s.Type {
+		if t != "null" {
+			schema.Type = t
+			break
+		}
+	}
+	if s.Items != nil && s.Items.Schema != nil {
+		schema.Items = newSchema(s.Items.Schema)
+	}
+	if s.AdditionalProperties != nil {
+		if s.AdditionalProperties.Schema != nil {
+			schema.AdditionalProperties = newSchema(s.AdditionalProperties.Schema)
+		} else if s.AdditionalProperties.Allows {
+			schema.AdditionalProperties = &Schema{}
+		}
+	}
+	for i := range s.AllOf {
+		schema.AllOf = append(schema.AllOf, newSchema(&s.AllOf[i]))
+	}
+	for _, name := range utils.SortedStringKeys(s.Properties) {
+		prop := s.Properties[name]
+		schema.Properties = append(schema.Properties, &Property{
+			Name:     name,
+			Required: utils.Contains(s.Required, name),
+			Schema:   newSchema(&prop),
+		})
+	}
+	if schema.Type == "" && len(schema.Properties) != 0 {
+		schema.Type = "object"
+	}
+	return schema
+}
+
+func isNullable(s *spec.Schema) bool {
+	if nullable, ok := s.Extensions.GetBool("x-nullable"); ok && nullable {
+		return true
+	}
+	return utils.Contains(s.Type, "null")
+}
+
+func isDeprecated(ext spec.Extensions) bool {
+	deprecated, ok := ext.GetBool("x-deprecated")
+	return ok && deprecated
+}
+
+func resolveParameter(swagger *spec.Swagger, p spec.Parameter) spec.Parameter {
+	if ref := p.Ref.String(); ref != "" {
+		if resolved, ok := swagger.Parameters[RefName(ref)]; ok {
+			return resolved
+		}
+	}
+	return p
+}
+
+func resolveResponse(swagger *spec.Swagger, r spec.Response) spec.Response {
+	if ref := r.Ref.String(); ref != "" {
+		if resolved, ok := swagger.Responses[RefName(ref)]; ok {
+			return resolved
+		}
+	}
+	return r
+}
@@ -0,0 +1,126 @@
+package model
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var pathParam = regexp.MustCompile(`{[a-z0-9A-Z_-]+}`)
+
+// RefName returns the last token of a json pointer, e.g. Pet for #/definitions/Pet
+func RefName(ref string) string {
+	tokens := strings.Split(ref, "/")
+	name := tokens[len(tokens)-1]
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(name)
+}
+
+// PathWith replaces the {name} parameters of the path with the result of param,
+// e.g. /users/${userId} for /users/{user_id}
+func PathWith(path string, param func(name string) string) string {
+	return pathParam.ReplaceAllStringFunc(path, func(matched string) string {
+		return param(matched[1 : len(matched)-1])
+	})
+}
+
+// Naming makes identifiers of a target language from the names of a spec
+type Naming struct {
+	// Reserved are the words which are not valid identifiers, they get a trailing _
+	Reserved map[string]bool
+}
+
+// TypeScriptNaming reserves the keywords of typescript, and the words which could not be a variable in strict mode
+var TypeScriptNaming = Naming{Reserved: words(`
+	break case catch class const continue debugger default delete do else enum export extends
+	false finally for function if import in instanceof new null return super switch this throw
+	true try typeof var void while with
+	arguments await eval implements interface let package private protected public static yield`)}
+
+func words(s string) map[string]bool {
+	m := make(map[string]bool)
+	for _, w := range strings.Fields(s) {
+		m[w] = true
+	}
+	return m
+}
+
+// Identifier returns format(name) as a valid identifier: other characters than letters, digits, _ and $ are dropped,
+// an identifier starting with a digit or an empty one gets a leading _, and a reserved word a trailing _,
+// e.g. _2fa for 2fa and class_ for class
+func (n Naming) Identifier(name string, format func(string) string) string {
+	id := strings.Map(func(r rune) rune {
+		if isIdentifierRune(r) {
+			return r
+		}
+		return -1
+	}, format(name))
+	if id == "" || unicode.IsDigit([]rune(id)[0]) {
+		id = "_" + id
+	}
+	if n.Reserved[id] {
+		id += "_"
+	}
+	return id
+}
+
+// IsIdentifier tells whether name is made of letters, digits, _ and $, and does not start with a digit.
+// A reserved word is an identifier, e.g. it could be the key of an object.
+func IsIdentifier(name string) bool {
+	for i, r := range name {
+		if !isIdentifierRune(r) || (i == 0 && unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return name != ""
+}
+
+func isIdentifierRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '$'
+}
+
+// scope hands out names which are unique in it
+type scope map[string]bool
+
+// add returns name, or name with the lowest number from 2 appended which is not taken yet
+func (s scope) add(name string) string {
+	unique := name
+	for i := 2; s[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	s[unique] = true
+	return unique
+}
+
+// addWith returns name like add, but the names made of it and each of the suffixes are free and taken too,
+// e.g. GET_PET, GET_PET_REQUEST and GET_PET_FAIL
+func (s scope) addWith(name string, suffixes ...string) string {
+	unique := name
+	for i := 2; s.takenWith(unique, suffixes); i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	for _, suffix := range suffixes {
+		s[unique+suffix] = true
+	}
+	return unique
+}
+
+func (s scope) takenWith(name string, suffixes []string) bool {
+	for _, suffix := range suffixes {
+		if s[name+suffix] {
+			return true
+		}
+	}
+	return false
+}
+
+// addFile returns name like add, but names differing in case only are taken too,
+// they are the same file on case-insensitive file systems
+func (s scope) addFile(name string) string {
+	unique := name
+	for i := 2; s[strings.ToLower(unique)]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	s[strings.ToLower(unique)] = true
+	return unique
+}
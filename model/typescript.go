@@ -0,0 +1,160 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TypeScript maps schemas to typescript types, it is shared by the typescript generators
+type TypeScript struct {
+	// Namespace is put before the names of definitions, e.g. schemas.
+	Namespace string
+	// Optional makes every property optional, not only the ones missing in required
+	Optional bool
+	// Formats maps the format of a scalar to a typescript type, e.g. int64 to string or date-time to Date
+	Formats map[string]string
+	// WireNames keeps the json names of properties, otherwise they are camel cased and converted by the codecs
+	WireNames bool
+	// Identifiers are the unique names of the model, see Identify. The names are not made unique if it is nil.
+	Identifiers *Identifiers
+}
+
+// Runtime types of formats, their values are converted from and to json
+const (
+	TypeDate   = "Date"
+	TypeBigInt = "bigint"
+)
+
+// Type returns the typescript type of the schema
+func (ts TypeScript) Type(s *Schema) string {
+	if s == nil {
+		return "any"
+	}
+
+	t := ts.baseType(s)
+	if s.Nullable && t != "any" {
+		t = t + " | null"
+	}
+	return t
+}
+
+// Property returns the declaration of the property in an interface, e.g. readonly name?: string
+func (ts TypeScript) Property(p *Property) string {
+	decl := ts.PropertyName(p)
+	if ts.Optional || !p.Required {
+		decl += "?"
+	}
+	decl += ": " + ts.Type(p.Schema)
+	if p.Schema != nil && p.Schema.ReadOnly {
+		decl = "readonly " + decl
+	}
+	return decl
+}
+
+// IsInterface tells whether the definition is declared as an interface, otherwise it is a type alias
+func (ts TypeScript) IsInterface(s *Schema) bool {
+	return s.Ref == "" && s.Type == "object" && len(s.Properties) != 0 &&
+		len(s.Enum) == 0 && len(s.AllOf) == 0 && s.AdditionalProperties == nil && !s.Nullable
+}
+
+func (ts TypeScript) baseType(s *Schema) string {
+	if s.Ref != "" {
+		return ts.Namespace + ts.DefinitionName(s.Ref)
+	}
+	if len(s.Enum) != 0 {
+		return literals(s.Enum)
+	}
+	if len(s.AllOf) != 0 {
+		var parts []string
+		for _, part := range s.AllOf {
+			parts = append(parts, group(ts.Type(part)))
+		}
+		if len(s.Properties) != 0 || s.AdditionalProperties != nil {
+			parts = append(parts, group(ts.object(s)))
+		}
+		return strings.Join(parts, " & ")
+	}
+
+	if t, ok := ts.Formats[s.Format]; ok && isScalar(s.Type) {
+		return t
+	}
+
+	switch s.Type {
+	case "integer", "number":
+		return "number"
+	case "string", "boolean":
+		return s.Type
+	case "array":
+		return group(ts.Type(s.Items)) + "[]"
+	case "file":
+		// File is a Blob, so that a Blob built in memory could be uploaded too
+		return "Blob"
+	case "object", "":
+		if s.Type == "object" || s.AdditionalProperties != nil {
+			return ts.object(s)
+		}
+	}
+	return "any"
+}
+
+// object returns an anonymous interface of the properties, or a record of the additional properties
+func (ts TypeScript) object(s *Schema) string {
+	var record string
+	if s.AdditionalProperties != nil {
+		record = "Record<string, " + ts.Type(s.AdditionalProperties) + ">"
+	}
+	if len(s.Properties) == 0 {
+		if record == "" {
+			return "Record<string, any>"
+		}
+		return record
+	}
+
+	props := make([]string, 0, len(s.Properties))
+	for _, p := range s.Properties {
+		props = append(props, ts.Property(p))
+	}
+	object := "{ " + strings.Join(props, "; ") + " }"
+	if record != "" {
+		return object + " & " + record
+	}
+	return object
+}
+
+func isScalar(t string) bool {
+	return t == "string" || t == "integer" || t == "number"
+}
+
+// literals returns the union of the enum values, e.g. 'a' | 'b'
+func literals(values []interface{}) string {
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		parts = append(parts, TypeScript{}.Literal(v))
+	}
+	return strings.Join(parts, " | ")
+}
+
+// Literal returns the value as a typescript literal, a string is quoted and a number or boolean is not, e.g. 'a' or 1
+func (ts TypeScript) Literal(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return quote(v)
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// quote returns the string as a single quoted literal
+func quote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`, "\n", `\n`, "\r", `\r`, "\t", `\t`).Replace(s) + "'"
+}
+
+// group puts a union or intersection in parentheses, so that it could be combined with other types
+func group(t string) string {
+	if strings.Contains(t, " | ") || strings.Contains(t, " & ") {
+		return "(" + t + ")"
+	}
+	return t
+}
@@ -0,0 +1,142 @@
+package output
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// modTime is the modification time of archived files, fixed to keep archives reproducible
+var modTime = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Zip collects the files and writes them sorted into a zip archive on Close
+type Zip struct {
+	*Memory
+	file string
+}
+
+// NewZip creates an output which writes a zip archive to file
+func NewZip(file string) *Zip {
+	return &Zip{Memory: NewMemory(), file: file}
+}
+
+// Close implements Output's Close method
+func (z *Zip) Close() error {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, name := range z.Names() {
+		f, err := w.CreateHeader(&zip.FileHeader{
+			Name:     name,
+			Method:   zip.Deflate,
+			Modified: modTime,
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(z.files[name]); err != nil {
+			return err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return writeArchive(z.file, buf.Bytes())
+}
+
+// Tar collects the files and writes them sorted into a tar archive on Close
+type Tar struct {
+	*Memory
+	file string
+	gzip bool
+}
+
+// NewTar creates an output which writes a tar archive to file, compressed with gzip if asked
+func NewTar(file string, gzip bool) *Tar {
+	return &Tar{Memory: NewMemory(), file: file, gzip: gzip}
+}
+
+// Close implements Output's Close method
+func (t *Tar) Close() error {
+	var buf bytes.Buffer
+	var w io.Writer = &buf
+	var gw *gzip.Writer
+	if t.gzip {
+		gw = gzip.NewWriter(&buf)
+		w = gw
+	}
+
+	tw := tar.NewWriter(w)
+	for _, name := range t.Names() {
+		data := t.files[name]
+		err := tw.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    0644,
+			Size:    int64(len(data)),
+			ModTime: modTime,
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if gw != nil {
+		if err := gw.Close(); err != nil {
+			return err
+		}
+	}
+
+	return writeArchive(t.file, buf.Bytes())
+}
+
+// writeArchive replaces the archive atomically if its content changed
+func writeArchive(file string, data []byte) error {
+	if onDisk, err := ioutil.ReadFile(file); err == nil && bytes.Equal(onDisk, data) {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(file), os.ModePerm); err != nil {
+		return err
+	}
+	return writeFileAtomic(file, data)
+}
+
+// Stdout prints the files one after another, each one preceded by a header line with its name
+type Stdout struct {
+	w io.Writer
+}
+
+// NewStdout creates an output which prints the files to w
+func NewStdout(w io.Writer) *Stdout {
+	return &Stdout{w: w}
+}
+
+// WriteFile implements Output's WriteFile method
+func (s *Stdout) WriteFile(name string, data []byte) error {
+	name, err := CleanName(name)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(s.w, "==> %s <==\n", name); err != nil {
+		return err
+	}
+	_, err = s.w.Write(data)
+	return err
+}
+
+// Close implements Output's Close method
+func (s *Stdout) Close() error {
+	return nil
+}
@@ -0,0 +1,60 @@
+package output
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// ManifestFile is written by a generator run into the output folder
+const ManifestFile = ".swagen-manifest.json"
+
+// Manifest lists the files produced by a generator run with the hashes of their content
+type Manifest struct {
+	Files map[string]string `json:"files"`
+}
+
+// ReadManifest reads the manifest in root, it returns nil if there is none
+func ReadManifest(root string) (*Manifest, error) {
+	data, err := ioutil.ReadFile(filepath.Join(root, ManifestFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	if m.Files == nil {
+		m.Files = make(map[string]string)
+	}
+	return m, nil
+}
+
+// Edited tells whether the file on disk was changed since it was generated.
+// Files unknown to the manifest are considered edited, they were not generated.
+func (m *Manifest) Edited(name string, onDisk []byte) bool {
+	hash, ok := m.Files[name]
+	return !ok || hash != Hash(onDisk)
+}
+
+// Hash returns the hash of the content of a file as stored in the manifest.
+// The content of user regions is not part of the hash.
+func Hash(data []byte) string {
+	sum := sha256.Sum256(StripRegions(data))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func (m *Manifest) marshal() ([]byte, error) {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
@@ -0,0 +1,224 @@
+package output
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xreception/go-swagen/utils"
+)
+
+// generate writes the files into root like a generator run
+func generate(root string, files map[string]string, force bool) ([]Change, error) {
+	dir, err := NewManifestDir(root, force)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range utils.SortedStringKeys(files) {
+		if err := dir.WriteFile(name, []byte(files[name])); err != nil {
+			return nil, err
+		}
+	}
+	err = dir.Close()
+	return dir.Changes(), err
+}
+
+// readTree returns the files under root but the manifest, by slash separated name
+func readTree(t *testing.T, root string) map[string]string {
+	files := make(map[string]string)
+	err := filepath.Walk(root, func(path string, fi os.FileInfo, err error) error {
+		if err != nil || fi.IsDir() || fi.Name() == ManifestFile {
+			return err
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(root, path)
+		files[filepath.ToSlash(rel)] = string(data)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+func TestManifestDir(t *testing.T) {
+	region := "a\n// swagen:begin custom\n// swagen:end\n"
+	tests := []struct {
+		name  string
+		first map[string]string
+		// edits are written between the runs, an empty content removes the file
+		edits   map[string]string
+		second  map[string]string
+		force   bool
+		want    map[string]string
+		changes []Change
+		err     string
+	}{
+		{
+			name:    "unchanged files are not written",
+			first:   map[string]string{"a.ts": "a", "b.ts": "b"},
+			second:  map[string]string{"a.ts": "a", "b.ts": "b2"},
+			want:    map[string]string{"a.ts": "a", "b.ts": "b2"},
+			changes: []Change{{"b.ts", Updated}},
+		},
+		{
+			name:    "stale files are removed with their empty folders",
+			first:   map[string]string{"a.ts": "a", "models/pet.ts": "pet"},
+			second:  map[string]string{"a.ts": "a", "user.ts": "user"},
+			want:    map[string]string{"a.ts": "a", "user.ts": "user"},
+			changes: []Change{{"user.ts", Added}, {"models/pet.ts", Removed}},
+		},
+		{
+			name:   "an edited file is not overwritten",
+			first:  map[string]string{"a.ts": "a", "b.ts": "b"},
+			edits:  map[string]string{"a.ts": "mine"},
+			second: map[string]string{"a.ts": "a2", "b.ts": "b2"},
+			want:   map[string]string{"a.ts": "mine", "b.ts": "b2"},
+			err:    "files were edited by hand, use --force to overwrite them: a.ts",
+		},
+		{
+			name:   "an edited stale file is not removed",
+			first:  map[string]string{"a.ts": "a", "b.ts": "b"},
+			edits:  map[string]string{"b.ts": "mine"},
+			second: map[string]string{"a.ts": "a"},
+			want:   map[string]string{"a.ts": "a", "b.ts": "mine"},
+			err:    "edited by hand, use --force to overwrite them: b.ts",
+		},
+		{
+			name:    "force overwrites and removes edited files",
+			first:   map[string]string{"a.ts": "a", "b.ts": "b"},
+			edits:   map[string]string{"a.ts": "mine", "b.ts": "mine"},
+			second:  map[string]string{"a.ts": "a2"},
+			force:   true,
+			want:    map[string]string{"a.ts": "a2"},
+			changes: []Change{{"a.ts", Updated}, {"b.ts", Removed}},
+		},
+		{
+			name:    "a removed file is written again",
+			first:   map[string]string{"a.ts": "a"},
+			edits:   map[string]string{"a.ts": ""},
+			second:  map[string]string{"a.ts": "a"},
+			want:    map[string]string{"a.ts": "a"},
+			changes: []Change{{"a.ts", Added}},
+		},
+		{
+			name:    "a file which was not generated is left alone",
+			first:   map[string]string{"a.ts": "a"},
+			edits:   map[string]string{"notes.md": "mine"},
+			second:  map[string]string{"a.ts": "a2"},
+			want:    map[string]string{"a.ts": "a2", "notes.md": "mine"},
+			changes: []Change{{"a.ts", Updated}},
+		},
+		{
+			name:    "editing a user region is no edit",
+			first:   map[string]string{"a.ts": region},
+			edits:   map[string]string{"a.ts": "a\n// swagen:begin custom\nmine()\n// swagen:end\n"},
+			second:  map[string]string{"a.ts": "a2\n// swagen:begin custom\n// swagen:end\n"},
+			want:    map[string]string{"a.ts": "a2\n// swagen:begin custom\nmine()\n// swagen:end\n"},
+			changes: []Change{{"a.ts", Updated}},
+		},
+		{
+			name:   "a stale file with a filled user region is not removed",
+			first:  map[string]string{"a.ts": "a", "files.ts": region},
+			edits:  map[string]string{"files.ts": "a\n// swagen:begin custom\nhello()\n// swagen:end\n"},
+			second: map[string]string{"a.ts": "a"},
+			want:   map[string]string{"a.ts": "a", "files.ts": "a\n// swagen:begin custom\nhello()\n// swagen:end\n"},
+			err:    "edited by hand, use --force to overwrite them: files.ts",
+		},
+		{
+			name:    "a stale file with empty user regions is removed",
+			first:   map[string]string{"a.ts": "a", "files.ts": region},
+			second:  map[string]string{"a.ts": "a"},
+			want:    map[string]string{"a.ts": "a"},
+			changes: []Change{{"files.ts", Removed}},
+		},
+		{
+			name:    "force removes a stale file with a filled user region",
+			first:   map[string]string{"a.ts": "a", "files.ts": region},
+			edits:   map[string]string{"files.ts": "a\n// swagen:begin custom\nhello()\n// swagen:end\n"},
+			second:  map[string]string{"a.ts": "a"},
+			force:   true,
+			want:    map[string]string{"a.ts": "a"},
+			changes: []Change{{"files.ts", Removed}},
+		},
+		{
+			name:   "a user region without place is kept",
+			first:  map[string]string{"a.ts": region},
+			edits:  map[string]string{"a.ts": "a\n// swagen:begin custom\nmine()\n// swagen:end\n"},
+			second: map[string]string{"a.ts": "a2\n"},
+			want:   map[string]string{"a.ts": "a\n// swagen:begin custom\nmine()\n// swagen:end\n"},
+			err:    "move their content or use --force to drop it: a.ts#custom",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := filepath.Join(t.TempDir(), "gen")
+			if _, err := generate(root, tt.first, false); err != nil {
+				t.Fatalf("first run: %v", err)
+			}
+			for name, content := range tt.edits {
+				path := filepath.Join(root, filepath.FromSlash(name))
+				var err error
+				if content == "" {
+					err = os.Remove(path)
+				} else {
+					err = ioutil.WriteFile(path, []byte(content), 0644)
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			changes, err := generate(root, tt.second, tt.force)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("second run error = %v, want %q", err, tt.err)
+				}
+			} else if err != nil {
+				t.Fatalf("second run: %v", err)
+			} else if !reflect.DeepEqual(changes, tt.changes) {
+				t.Errorf("second run changes = %v, want %v", changes, tt.changes)
+			}
+			if got := readTree(t, root); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("files after the second run = %v, want %v", got, tt.want)
+			}
+
+			// an edited file stays edited, the next run fails again
+			if tt.err != "" {
+				if _, err := generate(root, tt.second, false); err == nil {
+					t.Errorf("third run succeeded, want %q", tt.err)
+				}
+			}
+		})
+	}
+}
+
+func TestManifestEdited(t *testing.T) {
+	m := &Manifest{Files: map[string]string{"a.ts": Hash([]byte("a\n// swagen:begin x\n// swagen:end\n"))}}
+	tests := []struct {
+		name   string
+		file   string
+		onDisk string
+		want   bool
+	}{
+		{name: "same", file: "a.ts", onDisk: "a\n// swagen:begin x\n// swagen:end\n", want: false},
+		{name: "region edited", file: "a.ts", onDisk: "a\n// swagen:begin x\nmine()\n// swagen:end\n", want: false},
+		{name: "edited", file: "a.ts", onDisk: "b\n// swagen:begin x\n// swagen:end\n", want: true},
+		{name: "not generated", file: "b.ts", onDisk: "", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.Edited(tt.file, []byte(tt.onDisk)); got != tt.want {
+				t.Errorf("Edited() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
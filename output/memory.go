@@ -0,0 +1,131 @@
+package output
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// Memory keeps the files in memory
+type Memory struct {
+	files map[string][]byte
+}
+
+// NewMemory creates an empty in-memory output
+func NewMemory() *Memory {
+	return &Memory{files: make(map[string][]byte)}
+}
+
+// WriteFile implements Output's WriteFile method
+func (m *Memory) WriteFile(name string, data []byte) error {
+	name, err := CleanName(name)
+	if err != nil {
+		return err
+	}
+
+	m.files[name] = append([]byte(nil), data...)
+	return nil
+}
+
+// Close implements Output's Close method
+func (m *Memory) Close() error {
+	return nil
+}
+
+// Names returns the names of the files sorted
+func (m *Memory) Names() []string {
+	names := make([]string, 0, len(m.files))
+	for name := range m.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// File returns the content of the file with given name
+func (m *Memory) File(name string) ([]byte, bool) {
+	data, ok := m.files[name]
+	return data, ok
+}
+
+// CopyTo writes the files sorted by name to out
+func (m *Memory) CopyTo(out Output) error {
+	for _, name := range m.Names() {
+		if err := out.WriteFile(name, m.files[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Diff compares the files in memory with the ones in the root folder on disk.
+// Files listed in the manifest of root which are not in memory are shown as removed.
+// It returns a unified diff of the files which differ, or an empty string if all of them are up to date.
+func (m *Memory) Diff(root string) (string, error) {
+	manifest, err := ReadManifest(root)
+	if err != nil {
+		return "", err
+	}
+	names := m.Names()
+	if manifest != nil {
+		for name := range manifest.Files {
+			if _, ok := m.files[name]; !ok {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+	}
+
+	var out bytes.Buffer
+	for _, name := range names {
+		data, inMemory := m.files[name]
+		fromFile := "a/" + name
+		onDisk, err := ioutil.ReadFile(filepath.Join(root, filepath.FromSlash(name)))
+		exists := true
+		if os.IsNotExist(err) {
+			exists = false
+			fromFile = "/dev/null"
+		} else if err != nil {
+			return "", err
+		}
+		if !exists && !inMemory {
+			continue
+		}
+		if exists && inMemory {
+			// user regions are carried over when the file is written
+			if merged, _, err := MergeRegions(data, onDisk); err == nil {
+				data = merged
+			}
+			if bytes.Equal(onDisk, data) {
+				continue
+			}
+		}
+
+		var a, b []string
+		if len(onDisk) != 0 {
+			a = difflib.SplitLines(string(onDisk))
+		}
+		toFile := "/dev/null"
+		if inMemory {
+			b = difflib.SplitLines(string(data))
+			toFile = "b/" + name
+		}
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        a,
+			B:        b,
+			FromFile: fromFile,
+			ToFile:   toFile,
+			Context:  3,
+		})
+		if err != nil {
+			return "", err
+		}
+		out.WriteString(diff)
+	}
+
+	return out.String(), nil
+}
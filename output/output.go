@@ -0,0 +1,302 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/xreception/go-swagen/utils"
+)
+
+// Output is where generators write their files to.
+type Output interface {
+	// WriteFile writes the file, name is a slash separated path relative to the root of the output
+	WriteFile(name string, data []byte) error
+
+	// Close flushes the output, archives are only written on Close.
+	Close() error
+}
+
+// Open returns the output for the target given on the command line:
+// "-" is stdout, a .zip, .tar, .tar.gz or .tgz file is an archive, anything else is a folder
+// with a manifest, see NewManifestDir.
+func Open(target string, force bool) (Output, error) {
+	switch {
+	case target == "-":
+		return NewStdout(os.Stdout), nil
+	case strings.HasSuffix(target, ".zip"):
+		return NewZip(target), nil
+	case strings.HasSuffix(target, ".tar"):
+		return NewTar(target, false), nil
+	case strings.HasSuffix(target, ".tar.gz"), strings.HasSuffix(target, ".tgz"):
+		return NewTar(target, true), nil
+	}
+
+	if fi, err := os.Stat(target); err == nil && !fi.IsDir() {
+		return nil, fmt.Errorf("output %s is a file, expected a folder", target)
+	}
+	return NewManifestDir(target, force)
+}
+
+// Status of a file after it was written
+type Status string
+
+// Statuses of written files
+const (
+	Added   Status = "added"
+	Updated Status = "updated"
+	Removed Status = "removed"
+)
+
+// Change records a file which was touched by an output
+type Change struct {
+	Name   string
+	Status Status
+}
+
+// Dir writes files into a folder on disk.
+// Files are replaced atomically and only when their content changed,
+// so that file watchers do not fire needlessly.
+type Dir struct {
+	root    string
+	changes []Change
+
+	// manifest of the previous run, nil if the folder is not managed
+	manifest  *Manifest
+	force     bool
+	written   map[string]string
+	refused   []string
+	conflicts map[string][]string
+}
+
+// NewDir creates an output which writes into the root folder
+func NewDir(root string) *Dir {
+	return &Dir{root: root}
+}
+
+// NewManifestDir creates an output which manages the root folder with a manifest.
+// On Close the manifest is updated and the files of the previous run which were not written again are removed.
+// Files edited by hand since the previous run are neither overwritten nor removed unless force is true.
+func NewManifestDir(root string, force bool) (*Dir, error) {
+	manifest, err := ReadManifest(root)
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest in %s: %v", root, err)
+	}
+	if manifest == nil {
+		// the first run owns the folder, there is nothing to protect yet
+		manifest = &Manifest{Files: make(map[string]string)}
+		force = true
+	}
+
+	return &Dir{
+		root:      root,
+		manifest:  manifest,
+		force:     force,
+		written:   make(map[string]string),
+		conflicts: make(map[string][]string),
+	}, nil
+}
+
+// WriteFile implements Output's WriteFile method
+func (d *Dir) WriteFile(name string, data []byte) error {
+	name, err := CleanName(name)
+	if err != nil {
+		return err
+	}
+
+	if d.written != nil {
+		d.written[name] = Hash(data)
+	}
+
+	filePath := filepath.Join(d.root, filepath.FromSlash(name))
+	status := Updated
+	onDisk, err := ioutil.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		status = Added
+	} else if err != nil {
+		return err
+	} else if d.manifest != nil && !d.force && d.manifest.Edited(name, onDisk) {
+		d.refused = append(d.refused, name)
+		return nil
+	} else {
+		merged, lost, err := MergeRegions(data, onDisk)
+		if err != nil && !d.force {
+			return fmt.Errorf("%s: %v", name, err)
+		} else if err != nil {
+			merged, lost = data, nil
+		}
+		if len(lost) != 0 && !d.force {
+			if d.conflicts == nil {
+				return RegionConflictError{qualify(name, lost)}
+			}
+			d.conflicts[name] = lost
+			return nil
+		}
+		if bytes.Equal(onDisk, merged) {
+			return nil
+		}
+		data = merged
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+		return err
+	}
+	if err := writeFileAtomic(filePath, data); err != nil {
+		return err
+	}
+
+	d.changes = append(d.changes, Change{name, status})
+	return nil
+}
+
+// Changes returns the files which were added or updated, in the order they were written
+func (d *Dir) Changes() []Change {
+	return d.changes
+}
+
+// Close implements Output's Close method.
+// For a folder with a manifest, it removes the stale files and writes the new manifest.
+func (d *Dir) Close() error {
+	if d.manifest == nil {
+		return nil
+	}
+
+	next := &Manifest{Files: d.written}
+	kept := append([]string(nil), d.refused...)
+	for name := range d.conflicts {
+		kept = append(kept, name)
+	}
+	for _, name := range kept {
+		// keep the previous hash, the file stays edited for the next run
+		if hash, ok := d.manifest.Files[name]; ok {
+			next.Files[name] = hash
+		} else {
+			delete(next.Files, name)
+		}
+	}
+
+	var stale []string
+	for name := range d.manifest.Files {
+		if _, ok := d.written[name]; !ok {
+			stale = append(stale, name)
+		}
+	}
+	sort.Strings(stale)
+	for _, name := range stale {
+		filePath := filepath.Join(d.root, filepath.FromSlash(name))
+		onDisk, err := ioutil.ReadFile(filePath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		// code in the user regions is not part of the hash, a stale file keeps it only if it is refused
+		_, filled, _ := MergeRegions(nil, onDisk)
+		if !d.force && (d.manifest.Edited(name, onDisk) || len(filled) != 0) {
+			d.refused = append(d.refused, name)
+			next.Files[name] = d.manifest.Files[name]
+			continue
+		}
+		if err := os.Remove(filePath); err != nil {
+			return err
+		}
+		removeEmptyDirs(d.root, filepath.Dir(filePath))
+		d.changes = append(d.changes, Change{name, Removed})
+	}
+
+	data, err := next.marshal()
+	if err != nil {
+		return err
+	}
+	manifestPath := filepath.Join(d.root, ManifestFile)
+	if onDisk, err := ioutil.ReadFile(manifestPath); err != nil || !bytes.Equal(onDisk, data) {
+		if err := os.MkdirAll(d.root, os.ModePerm); err != nil {
+			return err
+		}
+		if err := writeFileAtomic(manifestPath, data); err != nil {
+			return err
+		}
+	}
+
+	var conflicts []string
+	for _, name := range utils.SortedStringKeys(d.conflicts) {
+		conflicts = append(conflicts, qualify(name, d.conflicts[name])...)
+	}
+	switch {
+	case len(d.refused) != 0 && len(conflicts) != 0:
+		return fmt.Errorf("%v; %v", EditedError{d.refused}, RegionConflictError{conflicts})
+	case len(d.refused) != 0:
+		return EditedError{d.refused}
+	case len(conflicts) != 0:
+		return RegionConflictError{conflicts}
+	}
+	return nil
+}
+
+// qualify prefixes the region names with the file name
+func qualify(name string, regions []string) []string {
+	qualified := make([]string, len(regions))
+	for i, r := range regions {
+		qualified[i] = name + "#" + r
+	}
+	return qualified
+}
+
+// removeEmptyDirs removes dir and its parents up to root as long as they are empty
+func removeEmptyDirs(root string, dir string) {
+	for {
+		rel, err := filepath.Rel(root, dir)
+		if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+			return
+		}
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// EditedError records generated files which were edited by hand and therefore not overwritten or removed.
+type EditedError struct {
+	Names []string
+}
+
+func (err EditedError) Error() string {
+	return fmt.Sprintf("files were edited by hand, use --force to overwrite them: %s", strings.Join(err.Names, ", "))
+}
+
+// writeFileAtomic writes to a temporary file next to filePath and renames it,
+// readers never see a partially written file.
+func writeFileAtomic(filePath string, data []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(filePath), "."+filepath.Base(filePath)+".swagen-")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), filePath)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+// CleanName normalizes the name of a file and makes sure it stays inside the root of the output
+func CleanName(name string) (string, error) {
+	clean := filepath.ToSlash(filepath.Clean(filepath.FromSlash(name)))
+	if clean == "." || filepath.IsAbs(name) || strings.HasPrefix(clean, "/") || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("file %s is outside of the output folder", name)
+	}
+	return clean, nil
+}
@@ -0,0 +1,133 @@
+package output
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// User regions are marked in generated files with comments, whatever the comment syntax of the language:
+//
+//	// swagen:begin custom
+//	hand written code, kept when the file is generated again
+//	// swagen:end
+var (
+	regionBegin = regexp.MustCompile(`swagen:begin\s+([\w.-]+)`)
+	regionEnd   = regexp.MustCompile(`swagen:end\b`)
+)
+
+// region is a user region, begin and end are the line indexes of its markers
+type region struct {
+	name  string
+	begin int
+	end   int
+}
+
+func splitLines(data []byte) []string {
+	if len(data) == 0 {
+		return nil
+	}
+	return strings.SplitAfter(string(data), "\n")
+}
+
+func findRegions(lines []string) ([]region, error) {
+	var regions []region
+	names := make(map[string]bool)
+	var open *region
+	for i, line := range lines {
+		if m := regionBegin.FindStringSubmatch(line); m != nil {
+			if open != nil {
+				return nil, fmt.Errorf("region %s starts inside region %s at line %d", m[1], open.name, i+1)
+			}
+			if names[m[1]] {
+				return nil, fmt.Errorf("region %s is defined twice", m[1])
+			}
+			names[m[1]] = true
+			open = &region{name: m[1], begin: i}
+		} else if regionEnd.MatchString(line) {
+			if open == nil {
+				return nil, fmt.Errorf("region end without begin at line %d", i+1)
+			}
+			open.end = i
+			regions = append(regions, *open)
+			open = nil
+		}
+	}
+	if open != nil {
+		return nil, fmt.Errorf("region %s is not closed", open.name)
+	}
+	return regions, nil
+}
+
+// MergeRegions carries the content of the user regions of previous over to the regions with the same name in generated.
+// It returns the merged content and the names of the non-empty regions of previous which have no place in generated.
+func MergeRegions(generated []byte, previous []byte) ([]byte, []string, error) {
+	prevLines := splitLines(previous)
+	prevRegions, err := findRegions(prevLines)
+	if err != nil {
+		return nil, nil, fmt.Errorf("previous file: %v", err)
+	}
+	genLines := splitLines(generated)
+	genRegions, err := findRegions(genLines)
+	if err != nil {
+		return nil, nil, fmt.Errorf("generated file: %v", err)
+	}
+	if len(prevRegions) == 0 {
+		return generated, nil, nil
+	}
+
+	content := make(map[string][]string)
+	for _, r := range prevRegions {
+		content[r.name] = prevLines[r.begin+1 : r.end]
+	}
+
+	var merged []string
+	last := 0
+	for _, r := range genRegions {
+		merged = append(merged, genLines[last:r.begin+1]...)
+		if lines, ok := content[r.name]; ok {
+			merged = append(merged, lines...)
+			delete(content, r.name)
+		} else {
+			merged = append(merged, genLines[r.begin+1:r.end]...)
+		}
+		last = r.end
+	}
+	merged = append(merged, genLines[last:]...)
+
+	var lost []string
+	for _, r := range prevRegions {
+		if lines, ok := content[r.name]; ok && strings.TrimSpace(strings.Join(lines, "")) != "" {
+			lost = append(lost, r.name)
+		}
+	}
+
+	return []byte(strings.Join(merged, "")), lost, nil
+}
+
+// StripRegions removes the content of the user regions, so that editing them does not count as editing the file
+func StripRegions(data []byte) []byte {
+	lines := splitLines(data)
+	regions, err := findRegions(lines)
+	if err != nil || len(regions) == 0 {
+		return data
+	}
+
+	var stripped []string
+	last := 0
+	for _, r := range regions {
+		stripped = append(stripped, lines[last:r.begin+1]...)
+		last = r.end
+	}
+	stripped = append(stripped, lines[last:]...)
+	return []byte(strings.Join(stripped, ""))
+}
+
+// RegionConflictError records user regions which would be lost because the generated files do not have them anymore.
+type RegionConflictError struct {
+	Conflicts []string
+}
+
+func (err RegionConflictError) Error() string {
+	return fmt.Sprintf("user regions have no place in the generated files, move their content or use --force to drop it: %s", strings.Join(err.Conflicts, ", "))
+}
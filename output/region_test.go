@@ -0,0 +1,139 @@
+package output
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMergeRegions(t *testing.T) {
+	tests := []struct {
+		name      string
+		generated string
+		previous  string
+		want      string
+		lost      []string
+		err       string
+	}{
+		{
+			name:      "no previous file",
+			generated: "a\n// swagen:begin custom\n// swagen:end\n",
+			want:      "a\n// swagen:begin custom\n// swagen:end\n",
+		},
+		{
+			name:      "previous without regions",
+			generated: "b\n",
+			previous:  "a\n",
+			want:      "b\n",
+		},
+		{
+			name:      "content is carried over",
+			generated: "b\n// swagen:begin custom\n// swagen:end\nc\n",
+			previous:  "a\n// swagen:begin custom\nmine()\nalso()\n// swagen:end\n",
+			want:      "b\n// swagen:begin custom\nmine()\nalso()\n// swagen:end\nc\n",
+		},
+		{
+			name:      "regions are matched by name",
+			generated: "# swagen:begin two\n# swagen:end\n# swagen:begin one\ndefault\n# swagen:end\n",
+			previous:  "# swagen:begin one\n1\n# swagen:end\n# swagen:begin two\n2\n# swagen:end\n",
+			want:      "# swagen:begin two\n2\n# swagen:end\n# swagen:begin one\n1\n# swagen:end\n",
+		},
+		{
+			name:      "a new region keeps its generated content",
+			generated: "// swagen:begin custom\n// swagen:end\n// swagen:begin imports\nimport x\n// swagen:end\n",
+			previous:  "// swagen:begin custom\nmine()\n// swagen:end\n",
+			want:      "// swagen:begin custom\nmine()\n// swagen:end\n// swagen:begin imports\nimport x\n// swagen:end\n",
+		},
+		{
+			name:      "a region without place is lost",
+			generated: "b\n",
+			previous:  "// swagen:begin custom\nmine()\n// swagen:end\n// swagen:begin empty\n\n// swagen:end\n",
+			want:      "b\n",
+			lost:      []string{"custom"},
+		},
+		{
+			name:      "last line without newline",
+			generated: "// swagen:begin custom\n// swagen:end",
+			previous:  "// swagen:begin custom\nmine()\n// swagen:end",
+			want:      "// swagen:begin custom\nmine()\n// swagen:end",
+		},
+		{
+			name:      "nested regions",
+			generated: "b\n",
+			previous:  "// swagen:begin a\n// swagen:begin b\n// swagen:end\n// swagen:end\n",
+			err:       "previous file: region b starts inside region a at line 2",
+		},
+		{
+			name:      "region defined twice",
+			generated: "// swagen:begin a\n// swagen:end\n// swagen:begin a\n// swagen:end\n",
+			err:       "generated file: region a is defined twice",
+		},
+		{
+			name:      "end without begin",
+			generated: "b\n",
+			previous:  "a\n// swagen:end\n",
+			err:       "region end without begin at line 2",
+		},
+		{
+			name:      "region not closed",
+			generated: "b\n",
+			previous:  "// swagen:begin a\n",
+			err:       "region a is not closed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, lost, err := MergeRegions([]byte(tt.generated), []byte(tt.previous))
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("MergeRegions() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MergeRegions() error = %v", err)
+			}
+			if string(merged) != tt.want {
+				t.Errorf("MergeRegions() = %q, want %q", merged, tt.want)
+			}
+			if !reflect.DeepEqual(lost, tt.lost) {
+				t.Errorf("MergeRegions() lost = %v, want %v", lost, tt.lost)
+			}
+		})
+	}
+}
+
+func TestStripRegions(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{name: "empty", data: "", want: ""},
+		{name: "no regions", data: "a\nb\n", want: "a\nb\n"},
+		{
+			name: "content is removed",
+			data: "a\n// swagen:begin custom\nmine()\n// swagen:end\nb\n",
+			want: "a\n// swagen:begin custom\n// swagen:end\nb\n",
+		},
+		{
+			name: "every region",
+			data: "// swagen:begin a\n1\n// swagen:end\n// swagen:begin b\n2\n3\n// swagen:end\n",
+			want: "// swagen:begin a\n// swagen:end\n// swagen:begin b\n// swagen:end\n",
+		},
+		{
+			name: "invalid regions are left as is",
+			data: "// swagen:begin a\n1\n",
+			want: "// swagen:begin a\n1\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(StripRegions([]byte(tt.data))); got != tt.want {
+				t.Errorf("StripRegions() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
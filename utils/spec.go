@@ -28,15 +28,17 @@ func LoadSpec(input string) (*spec.Swagger, error) {
 	return nil, nil
 }
 
-// WriteToFile dump inmemory swagger to file
-func WriteToFile(swspec *spec.Swagger, pretty bool, output string) error {
-	var b []byte
-	var err error
+// MarshalSpec dump inmemory swagger to json
+func MarshalSpec(swspec *spec.Swagger, pretty bool) ([]byte, error) {
 	if pretty {
-		b, err = json.MarshalIndent(swspec, "", "  ")
-	} else {
-		b, err = json.Marshal(swspec)
+		return json.MarshalIndent(swspec, "", "  ")
 	}
+	return json.Marshal(swspec)
+}
+
+// WriteToFile dump inmemory swagger to file
+func WriteToFile(swspec *spec.Swagger, pretty bool, output string) error {
+	b, err := MarshalSpec(swspec, pretty)
 	if err != nil {
 		return err
 	}
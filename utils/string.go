@@ -19,6 +19,20 @@ func Split(str string) []string {
 	return re.FindAllString(strings.Join(strs, ""), 1000)
 }
 
+var word = regexp.MustCompile(`(?:^[a-z]|[A-Z]+|[0-9]+)[a-z0-9]*`)
+
+// Words splits a name into words, at characters which are not letters or digits and at case changes,
+// e.g. Request, Id for x-request-id
+func Words(str string) []string {
+	var words []string
+	for _, field := range strings.FieldsFunc(str, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		words = append(words, word.FindAllString(upperFirst(field), -1)...)
+	}
+	return words
+}
+
 func upperFirst(s string) string {
 	if s == "" {
 		return ""
@@ -29,7 +43,7 @@ func upperFirst(s string) string {
 
 // CamelCase convert a string to camel case
 func CamelCase(s string) string {
-	ss := Split(s)
+	ss := Words(s)
 	for i := range ss {
 		ss[i] = strings.ToLower(ss[i])
 		return strings.Join(ss, "")
@@ -39,14 +53,14 @@ func CamelCase(s string) string {
 
 // UpperSnakeCase convert a string to snake case
 func UpperSnakeCase(s string) string {
-	ss := Split(s)
+	ss := Words(s)
 	result := strings.Join(ss, "_")
 	return strings.ToUpper(result)
 }
 
 // InterfaceCase convert a string to interface name start with I
 func InterfaceCase(s string) string {
-	ss := Split(s)
+	ss := Words(s)
 	return "I" + strings.Join(ss, "")
 }
 
@@ -0,0 +1,194 @@
+package watcher
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watcher reports changes of a set of files.
+// Changes are debounced: a batch is sent once no file changed for the debounce duration.
+type Watcher struct {
+	files    map[string]bool
+	debounce time.Duration
+	changes  chan []string
+	errors   chan error
+	done     chan struct{}
+	notify   *fsnotify.Watcher
+}
+
+// New watches files with inotify like events of the os, or by polling every interval if poll is true.
+func New(files []string, debounce time.Duration, poll bool, interval time.Duration) (*Watcher, error) {
+	w := &Watcher{
+		files:    make(map[string]bool),
+		debounce: debounce,
+		changes:  make(chan []string),
+		errors:   make(chan error),
+		done:     make(chan struct{}),
+	}
+	for _, file := range files {
+		abs, err := filepath.Abs(file)
+		if err != nil {
+			return nil, err
+		}
+		w.files[abs] = true
+	}
+
+	events := make(chan string)
+	if poll {
+		// the first stamps are taken before New returns, so that no later change is missed
+		stamps := make(map[string]stamp)
+		for file := range w.files {
+			stamps[file] = stat(file)
+		}
+		go w.poll(events, stamps, interval)
+	} else {
+		if err := w.watch(events); err != nil {
+			return nil, err
+		}
+	}
+	go w.collect(events)
+
+	return w, nil
+}
+
+// Changes returns the channel of changed files
+func (w *Watcher) Changes() <-chan []string {
+	return w.changes
+}
+
+// Errors returns the channel of watching errors
+func (w *Watcher) Errors() <-chan error {
+	return w.errors
+}
+
+// Close stops watching
+func (w *Watcher) Close() error {
+	close(w.done)
+	if w.notify != nil {
+		return w.notify.Close()
+	}
+	return nil
+}
+
+// watch listens to the folders of the files, editors often replace a file instead of writing it
+func (w *Watcher) watch(events chan<- string) error {
+	notify, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	w.notify = notify
+
+	dirs := make(map[string]bool)
+	for file := range w.files {
+		dirs[filepath.Dir(file)] = true
+	}
+	for dir := range dirs {
+		if err := notify.Add(dir); err != nil {
+			notify.Close()
+			return err
+		}
+	}
+
+	go func() {
+		for {
+			select {
+			case ev, ok := <-notify.Events:
+				if !ok {
+					return
+				}
+				if w.files[filepath.Clean(ev.Name)] && ev.Op&fsnotify.Chmod != ev.Op {
+					// collect is gone once the watcher is closed
+					select {
+					case events <- filepath.Clean(ev.Name):
+					case <-w.done:
+						return
+					}
+				}
+			case err, ok := <-notify.Errors:
+				if !ok {
+					return
+				}
+				w.sendError(err)
+			case <-w.done:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+type stamp struct {
+	modTime time.Time
+	size    int64
+	exists  bool
+}
+
+func stat(file string) stamp {
+	fi, err := os.Stat(file)
+	if err != nil {
+		return stamp{}
+	}
+	return stamp{fi.ModTime(), fi.Size(), true}
+}
+
+// poll compares modification time and size of the files every interval
+func (w *Watcher) poll(events chan<- string, stamps map[string]stamp, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			for file, last := range stamps {
+				if now := stat(file); now != last {
+					stamps[file] = now
+					select {
+					case events <- file:
+					case <-w.done:
+						return
+					}
+				}
+			}
+		case <-w.done:
+			return
+		}
+	}
+}
+
+// collect batches the events until no file changed for the debounce duration
+func (w *Watcher) collect(events <-chan string) {
+	pending := make(map[string]bool)
+	timer := time.NewTimer(w.debounce)
+	timer.Stop()
+	for {
+		select {
+		case file := <-events:
+			pending[file] = true
+			timer.Reset(w.debounce)
+		case <-timer.C:
+			batch := make([]string, 0, len(pending))
+			for file := range pending {
+				batch = append(batch, file)
+			}
+			sort.Strings(batch)
+			pending = make(map[string]bool)
+			select {
+			case w.changes <- batch:
+			case <-w.done:
+				return
+			}
+		case <-w.done:
+			return
+		}
+	}
+}
+
+func (w *Watcher) sendError(err error) {
+	select {
+	case w.errors <- err:
+	case <-w.done:
+	}
+}
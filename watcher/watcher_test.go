@@ -0,0 +1,79 @@
+package watcher
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestWatcher(t *testing.T) {
+	for _, poll := range []bool{true, false} {
+		t.Run("poll="+strconv.FormatBool(poll), func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "swagger.json")
+			if err := ioutil.WriteFile(file, []byte("{}"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			w, err := New([]string{file}, 20*time.Millisecond, poll, 10*time.Millisecond)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer w.Close()
+
+			if err := ioutil.WriteFile(file, []byte(`{"swagger": "2.0"}`), 0644); err != nil {
+				t.Fatal(err)
+			}
+			select {
+			case changed := <-w.Changes():
+				if !reflect.DeepEqual(changed, []string{file}) {
+					t.Errorf("Changes() = %v, want %v", changed, []string{file})
+				}
+			case err := <-w.Errors():
+				t.Fatal(err)
+			case <-time.After(5 * time.Second):
+				t.Fatal("no change reported")
+			}
+		})
+	}
+}
+
+func TestWatcherClose(t *testing.T) {
+	for _, poll := range []bool{true, false} {
+		t.Run("poll="+strconv.FormatBool(poll), func(t *testing.T) {
+			before := runtime.NumGoroutine()
+			file := filepath.Join(t.TempDir(), "swagger.json")
+			w, err := New([]string{file}, time.Hour, poll, time.Millisecond)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// changes keep coming while nobody reads the batches
+			for i := 0; i < 20; i++ {
+				if err := ioutil.WriteFile(file, []byte(strconv.Itoa(i*i)), 0644); err != nil {
+					t.Fatal(err)
+				}
+				time.Sleep(2 * time.Millisecond)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+			for i := 0; i < 5; i++ {
+				if err := ioutil.WriteFile(file, []byte(strconv.Itoa(i)), 0644); err != nil {
+					t.Fatal(err)
+				}
+				time.Sleep(2 * time.Millisecond)
+			}
+
+			deadline := time.Now().Add(5 * time.Second)
+			for runtime.NumGoroutine() > before {
+				if time.Now().After(deadline) {
+					t.Fatalf("%d goroutines left after Close, %d before New", runtime.NumGoroutine(), before)
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+		})
+	}
+}